@@ -3,7 +3,6 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
-	"io/fs"
 	"math"
 	"os"
 	"path/filepath"
@@ -11,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/orangekame3/qasmtools/discover"
 	"github.com/orangekame3/qasmtools/parser"
 	"github.com/spf13/cobra"
 )
@@ -69,6 +69,7 @@ func NewBenchmarkCommand() *cobra.Command {
 	cmd.Flags().String("filter", "*.qasm", "File pattern to match")
 	cmd.Flags().Bool("recursive", true, "Search subdirectories recursively")
 	cmd.Flags().Bool("ignore-errors", false, "Continue benchmarking even if some files fail")
+	addDiscoveryFlags(cmd)
 	cmd.Flags().String("suite-name", "qasmtools", "Name of the benchmark suite")
 
 	return cmd
@@ -84,6 +85,8 @@ func runBenchmark(cmd *cobra.Command, args []string) error {
 	recursive, _ := cmd.Flags().GetBool("recursive")
 	ignoreErrors, _ := cmd.Flags().GetBool("ignore-errors")
 	suiteName, _ := cmd.Flags().GetString("suite-name")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	noIgnore, _ := cmd.Flags().GetBool("no-ignore")
 
 	// Determine directory to benchmark
 	directory := "."
@@ -92,7 +95,15 @@ func runBenchmark(cmd *cobra.Command, args []string) error {
 	}
 
 	// Find QASM files
-	files, err := findQASMFiles(directory, filter, recursive)
+	pattern := directory
+	if recursive {
+		pattern = filepath.Join(directory, "...")
+	}
+	files, err := discover.Files([]string{pattern}, discover.Options{
+		Match:         filter,
+		Exclude:       exclude,
+		NoIgnoreFiles: noIgnore,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to find QASM files: %w", err)
 	}
@@ -144,42 +155,6 @@ func runBenchmark(cmd *cobra.Command, args []string) error {
 	}
 }
 
-func findQASMFiles(directory, pattern string, recursive bool) ([]string, error) {
-	var files []string
-
-	if recursive {
-		err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return err
-			}
-
-			if !d.IsDir() {
-				if matched, _ := filepath.Match(pattern, filepath.Base(path)); matched {
-					files = append(files, path)
-				}
-			}
-			return nil
-		})
-		return files, err
-	}
-
-	// Non-recursive search
-	entries, err := os.ReadDir(directory)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			if matched, _ := filepath.Match(pattern, entry.Name()); matched {
-				files = append(files, filepath.Join(directory, entry.Name()))
-			}
-		}
-	}
-
-	return files, nil
-}
-
 func benchmarkFile(filename string, runs, warmup int) BenchmarkResult {
 	result := BenchmarkResult{
 		Filename: filename,
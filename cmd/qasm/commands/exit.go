@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exit codes of the qasm command
+const (
+	// ExitOK means the command succeeded and found nothing to report
+	ExitOK = 0
+	// ExitViolations means lint found violations that fail the run, or
+	// fmt --check found unformatted files
+	ExitViolations = 1
+	// ExitFailure means the command could not run, e.g. because of invalid
+	// flags or files that cannot be read or parsed
+	ExitFailure = 2
+)
+
+// ExitError is returned by commands that completed but must exit with a
+// non-zero code. Its output has already been written, so it is not printed.
+type ExitError struct {
+	Code int
+}
+
+// Error implements error
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ExitCode returns the exit code for an error returned by a command
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/discover"
+	"github.com/spf13/cobra"
+)
+
+// addDiscoveryFlags adds the flags that control which files directory and
+// glob arguments expand to
+func addDiscoveryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("exclude", []string{}, "Skip files and directories matching gitignore-style patterns (comma-separated)")
+	cmd.Flags().Bool("no-ignore", false, "Do not skip files listed in .gitignore and .qasmignore")
+}
+
+// discoverFiles expands file, directory ("dir", "dir/...") and glob arguments
+// into the files to process
+func discoverFiles(cmd *cobra.Command, args []string, opts discover.Options) ([]string, error) {
+	opts.Exclude, _ = cmd.Flags().GetStringSlice("exclude")
+	opts.NoIgnoreFiles, _ = cmd.Flags().GetBool("no-ignore")
+
+	files, err := discover.Files(args, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no QASM files found in %v", args)
+	}
+	return files, nil
+}
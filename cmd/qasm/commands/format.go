@@ -8,6 +8,7 @@ import (
 	"github.com/mattn/go-isatty"
 	"github.com/orangekame3/qasmtools/cmd/qasm/config"
 	"github.com/orangekame3/qasmtools/cmd/qasm/ioutils"
+	"github.com/orangekame3/qasmtools/discover"
 	"github.com/orangekame3/qasmtools/formatter"
 	"github.com/spf13/cobra"
 )
@@ -15,10 +16,13 @@ import (
 // NewFormatCommand creates the format command
 func NewFormatCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "fmt [files...]",
+		Use:   "fmt [files|dirs|patterns...]",
 		Short: "Format QASM files",
-		Long:  `Format one or more QASM files according to the standard style.`,
-		RunE:  runFormat,
+		Long: `Format one or more QASM files according to the standard style.
+
+Directories are formatted with their .qasm and .inc files; "dir/..." also
+includes subdirectories. Files in .gitignore or .qasmignore are skipped.`,
+		RunE: runFormat,
 	}
 
 	// Add flags
@@ -28,6 +32,7 @@ func NewFormatCommand() *cobra.Command {
 	cmd.Flags().UintP("indent", "i", 2, "Number of spaces for indentation")
 	cmd.Flags().Bool("newline", true, "Add newline at end of file")
 	cmd.Flags().BoolP("verbose", "v", false, "Verbose output")
+	addDiscoveryFlags(cmd)
 
 	return cmd
 }
@@ -72,7 +77,7 @@ func RunFormatStdin(cmd *cobra.Command, config *formatter.Config) error {
 	if config.Check {
 		if string(input) != formatted {
 			fmt.Fprintln(os.Stderr, "stdin: not formatted")
-			os.Exit(1)
+			return &ExitError{Code: ExitViolations}
 		}
 		return nil
 	}
@@ -82,10 +87,15 @@ func RunFormatStdin(cmd *cobra.Command, config *formatter.Config) error {
 }
 
 func runFormatWithConfig(cmd *cobra.Command, args []string, config *formatter.Config) error {
+	files, err := discoverFiles(cmd, args, discover.Options{})
+	if err != nil {
+		return err
+	}
+
 	hasError := false
 	hasChanges := false
 
-	for _, filename := range args {
+	for _, filename := range files {
 		changed, err := FormatFileWithConfig(filename, config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error formatting %s: %v\n", filename, err)
@@ -102,7 +112,7 @@ func runFormatWithConfig(cmd *cobra.Command, args []string, config *formatter.Co
 	}
 
 	if config.Check && hasChanges {
-		os.Exit(1)
+		return &ExitError{Code: ExitViolations}
 	}
 
 	return nil
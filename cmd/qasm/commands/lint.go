@@ -1,13 +1,14 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/orangekame3/qasmtools/discover"
 	"github.com/orangekame3/qasmtools/lint"
 	"github.com/spf13/cobra"
 )
@@ -15,30 +16,56 @@ import (
 // NewLintCommand creates the lint command
 func NewLintCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "lint [files...]",
+		Use:   "lint [files|dirs|patterns...]",
 		Short: "Lint QASM files for errors and style issues",
-		Long:  `Analyze QASM files for potential errors, style violations, and best practice issues.`,
-		RunE:  runLint,
+		Long: `Analyze QASM files for potential errors, style violations, and best practice issues.
+
+Directories are linted with their .qasm and .inc files; "dir/..." also includes
+subdirectories. Files in .gitignore or .qasmignore are skipped.
+
+Exit codes:
+  0  no reported violation fails the run
+  1  violations at or above --fail-on were reported, or more warnings than --max-warnings
+  2  linting failed, e.g. because of invalid flags, files that cannot be read or syntax errors`,
+		RunE: runLint,
 	}
 
 	// Add flags
 	cmd.Flags().String("rules", "", "Rules directory")
 	cmd.Flags().StringSlice("disable", []string{}, "Disable specific rules (comma-separated)")
 	cmd.Flags().StringSlice("enable-only", []string{}, "Enable only specific rules (comma-separated)")
-	cmd.Flags().String("format", "text", "Output format (text, json)")
+	cmd.Flags().String("format", "text", "Output format (text, json, checkstyle, junit, github)")
 	cmd.Flags().BoolP("quiet", "q", false, "Only show errors, not warnings")
+	cmd.Flags().StringSlice("severity", []string{}, "Override rule severities, e.g. QAS005=error (comma-separated)")
+	cmd.Flags().String("fail-on", "info", "Lowest severity of reported violations that fails the run (error, warning, info)")
+	cmd.Flags().Int("max-warnings", -1, "Fail the run when more than this many warnings are reported (-1 for no limit)")
 	cmd.Flags().Bool("no-color", false, "Disable colored output")
+	cmd.Flags().Bool("no-code-frame", false, "Do not show the source line of each violation in text output")
 	cmd.Flags().BoolP("verbose", "v", false, "Verbose output")
 	cmd.Flags().Bool("use-ast", true, "Use AST-based analysis (faster and more accurate)")
 	cmd.Flags().Bool("parallel", true, "Enable parallel processing for multiple files")
 	cmd.Flags().Int("workers", 4, "Number of worker threads for parallel processing")
 	cmd.Flags().Bool("performance", false, "Show performance statistics")
+	addDiscoveryFlags(cmd)
+	cmd.Flags().Bool("cache", false, "Reuse results for files that have not changed since the last run")
+	cmd.Flags().String("cache-dir", "", "Directory of the lint cache (default: user cache directory)")
 	cmd.Flags().Bool("stdin", false, "Read from stdin")
+	cmd.Flags().String("baseline", "", "Only report violations not recorded in the given baseline file")
+	cmd.Flags().String("write-baseline", "", "Record current violations in the given baseline file")
+	cmd.Flags().Bool("fix", false, "Apply available fixes to the files and report the remaining violations")
+	cmd.Flags().String("target", "", "Hardware target profile (YAML or JSON) to check native gates, qubit count and connectivity against")
+	cmd.Flags().String("explain", "", "Explain a rule with examples instead of linting (same as qasm rules explain)")
 
 	return cmd
 }
 
 func runLint(cmd *cobra.Command, args []string) error {
+	if ruleID, _ := cmd.Flags().GetString("explain"); ruleID != "" {
+		rulesDir, _ := cmd.Flags().GetString("rules")
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		return explainRule(cmd.OutOrStdout(), rulesDir, ruleID, !noColor)
+	}
+
 	stdin, _ := cmd.Flags().GetBool("stdin")
 
 	// Check if we should read from stdin
@@ -50,58 +77,250 @@ func runLint(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("at least one file is required")
 	}
 
+	policy, err := loadFailPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	files, err := discoverFiles(cmd, args, discover.Options{})
+	if err != nil {
+		return err
+	}
+
 	rulesDir, _ := cmd.Flags().GetString("rules")
 	disabled, _ := cmd.Flags().GetStringSlice("disable")
 	enabledOnly, _ := cmd.Flags().GetStringSlice("enable-only")
 	format, _ := cmd.Flags().GetString("format")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	noColor, _ := cmd.Flags().GetBool("no-color")
+	noCodeFrame, _ := cmd.Flags().GetBool("no-code-frame")
 	useAST, _ := cmd.Flags().GetBool("use-ast")
 	parallel, _ := cmd.Flags().GetBool("parallel")
 	workers, _ := cmd.Flags().GetInt("workers")
 	showPerf, _ := cmd.Flags().GetBool("performance")
 
-	// Create optimized linter based on configuration
-	var violations []*lint.Violation
-	var err error
+	fix, _ := cmd.Flags().GetBool("fix")
 
-	if parallel && len(args) > 1 {
-		// Use batch linter for multiple files
-		batchLinter := lint.NewBatchLinter(rulesDir, workers)
-		err = batchLinter.LoadRules()
-		if err != nil {
-			return fmt.Errorf("failed to load rules: %w", err)
-		}
-		violations, err = batchLinter.LintFilesParallel(args)
-		if showPerf {
-			printPerformanceStats(batchLinter.GetStats())
+	target, err := loadTarget(cmd)
+	if err != nil {
+		return err
+	}
+
+	cache, err := openCache(cmd)
+	if err != nil {
+		return err
+	}
+
+	// Create optimized linter based on configuration
+	lintFiles := func() ([]*lint.Violation, error) {
+		if parallel && len(files) > 1 {
+			// Use batch linter for multiple files
+			batchLinter := lint.NewBatchLinter(rulesDir, workers)
+			batchLinter.SetTarget(target)
+			batchLinter.SetCache(cache)
+			if err := batchLinter.LoadRules(); err != nil {
+				return nil, fmt.Errorf("failed to load rules: %w", err)
+			}
+			violations, err := batchLinter.LintFilesParallel(files)
+			if showPerf {
+				printPerformanceStats(batchLinter.GetStats())
+			}
+			return violations, err
 		}
-	} else {
+
 		// Use standard linter
 		linter := lint.NewLinterWithAST(rulesDir, useAST)
-		err = linter.LoadRules()
-		if err != nil {
-			return fmt.Errorf("failed to load rules: %w", err)
+		linter.SetTarget(target)
+		linter.SetCache(cache)
+		if err := linter.LoadRules(); err != nil {
+			return nil, fmt.Errorf("failed to load rules: %w", err)
 		}
-		violations, err = linter.LintFiles(args)
+		return linter.LintFiles(files)
 	}
 
+	violations, err := lintFiles()
 	if err != nil {
 		return fmt.Errorf("failed to lint files: %w", err)
 	}
+	if showPerf && cache != nil {
+		hits, misses := cache.Stats()
+		fmt.Printf("   Cache hits: %d of %d files\n", hits, hits+misses)
+	}
 
 	// Filter violations based on flags
+	policy.overrideSeverities(violations)
 	filteredViolations := filterViolations(violations, disabled, enabledOnly, quiet)
 
+	// Apply fixes and lint the corrected files again
+	if fix {
+		fixed, err := fixFiles(filteredViolations)
+		if err != nil {
+			return err
+		}
+		if fixed > 0 {
+			fmt.Fprintf(os.Stderr, "🔧 Applied %d fixes\n", fixed)
+			violations, err = lintFiles()
+			if err != nil {
+				return fmt.Errorf("failed to lint files: %w", err)
+			}
+			policy.overrideSeverities(violations)
+			filteredViolations = filterViolations(violations, disabled, enabledOnly, quiet)
+		}
+	}
+
+	// Apply or record the baseline
+	filteredViolations, done, err := applyBaseline(cmd, filteredViolations, nil)
+	if err != nil || done {
+		return err
+	}
+
 	// Output results
-	switch format {
-	case "json":
-		return outputJSON(filteredViolations)
-	default:
-		return outputTextWithColor(filteredViolations, !noColor)
+	text := &textReporter{useColor: !noColor, codeFrame: !noCodeFrame}
+	if err := reportViolations(os.Stdout, filteredViolations, format, text); err != nil {
+		return err
+	}
+	return policy.check(filteredViolations)
+}
+
+// failPolicy holds the flags deciding the severity of violations and whether
+// the reported violations fail a lint run
+type failPolicy struct {
+	// overrides maps rule IDs to the severity of their violations
+	overrides map[string]lint.Severity
+	// failOn is the lowest severity that fails the run
+	failOn lint.Severity
+	// maxWarnings is the number of warnings tolerated, or -1 for no limit
+	maxWarnings int
+}
+
+// loadFailPolicy reads the --severity, --fail-on and --max-warnings flags
+func loadFailPolicy(cmd *cobra.Command) (*failPolicy, error) {
+	overrides, _ := cmd.Flags().GetStringSlice("severity")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	maxWarnings, _ := cmd.Flags().GetInt("max-warnings")
+
+	policy := &failPolicy{
+		overrides:   make(map[string]lint.Severity),
+		failOn:      lint.Severity(failOn),
+		maxWarnings: maxWarnings,
+	}
+	if !policy.failOn.Valid() {
+		return nil, fmt.Errorf("invalid --fail-on %q (expected error, warning or info)", failOn)
+	}
+	if maxWarnings < -1 {
+		return nil, fmt.Errorf("invalid --max-warnings %d (expected -1 or more)", maxWarnings)
+	}
+
+	for _, override := range overrides {
+		ruleID, severity, ok := strings.Cut(override, "=")
+		if !ok || ruleID == "" || !lint.Severity(severity).Valid() {
+			return nil, fmt.Errorf("invalid --severity %q (expected RULE=error, RULE=warning or RULE=info)", override)
+		}
+		policy.overrides[ruleID] = lint.Severity(severity)
+	}
+
+	return policy, nil
+}
+
+// overrideSeverities sets the severity of violations of rules given to --severity
+func (p *failPolicy) overrideSeverities(violations []*lint.Violation) {
+	for _, violation := range violations {
+		if severity, ok := p.overrides[violation.Rule.ID]; ok {
+			violation.Severity = severity
+		}
 	}
 }
 
+// check returns an ExitError when the reported violations fail the run
+func (p *failPolicy) check(violations []*lint.Violation) error {
+	failed := false
+	warnings := 0
+	for _, violation := range violations {
+		if violation.Severity.AtLeast(p.failOn) {
+			failed = true
+		}
+		if violation.Severity == lint.SeverityWarning {
+			warnings++
+		}
+	}
+
+	if p.maxWarnings >= 0 && warnings > p.maxWarnings {
+		fmt.Fprintf(os.Stderr, "✖ Found %d warnings, more than the maximum of %d\n", warnings, p.maxWarnings)
+		failed = true
+	}
+
+	if failed {
+		return &ExitError{Code: ExitViolations}
+	}
+	return nil
+}
+
+// openCache opens the lint cache when --cache is given, returning nil otherwise
+func openCache(cmd *cobra.Command) (*lint.Cache, error) {
+	if enabled, _ := cmd.Flags().GetBool("cache"); !enabled {
+		return nil, nil
+	}
+
+	dir, _ := cmd.Flags().GetString("cache-dir")
+	if dir == "" {
+		var err error
+		dir, err = lint.DefaultCacheDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lint.NewCache(dir)
+}
+
+// loadTarget loads the --target hardware profile, returning nil when none is given
+func loadTarget(cmd *cobra.Command) (*lint.Target, error) {
+	path, _ := cmd.Flags().GetString("target")
+	if path == "" {
+		return nil, nil
+	}
+	return lint.LoadTarget(path)
+}
+
+// fixFiles applies the fixes of violations to their files and returns the
+// number of fixes applied
+func fixFiles(violations []*lint.Violation) (int, error) {
+	byFile := make(map[string][]*lint.Violation)
+	var files []string
+	for _, violation := range violations {
+		if violation.Fix == nil {
+			continue
+		}
+		if _, seen := byFile[violation.File]; !seen {
+			files = append(files, violation.File)
+		}
+		byFile[violation.File] = append(byFile[violation.File], violation)
+	}
+
+	total := 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return total, fmt.Errorf("failed to read file %s: %w", file, err)
+		}
+
+		fixed, count := lint.ApplyFixes(string(content), byFile[file])
+		if count == 0 {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return total, fmt.Errorf("failed to stat file %s: %w", file, err)
+		}
+		if err := os.WriteFile(file, []byte(fixed), info.Mode().Perm()); err != nil {
+			return total, fmt.Errorf("failed to write file %s: %w", file, err)
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
 func filterViolations(violations []*lint.Violation, disabled []string, enabledOnly []string, quiet bool) []*lint.Violation {
 	var filtered []*lint.Violation
 
@@ -137,21 +356,93 @@ func filterViolations(violations []*lint.Violation, disabled []string, enabledOn
 	return filtered
 }
 
-// outputJSON outputs violations in JSON format
-func outputJSON(violations []*lint.Violation) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(violations)
+// applyBaseline writes a new baseline when --write-baseline is given, or removes
+// violations recorded in the --baseline file. It returns done=true when the
+// baseline was written and no further output is needed.
+func applyBaseline(cmd *cobra.Command, violations []*lint.Violation, sources map[string]string) ([]*lint.Violation, bool, error) {
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	writeBaselinePath, _ := cmd.Flags().GetString("write-baseline")
+
+	if writeBaselinePath != "" {
+		baseline := lint.NewBaseline()
+		for file, content := range sources {
+			baseline.SetSource(file, content)
+		}
+		baseline.Add(violations)
+		if err := baseline.Save(writeBaselinePath); err != nil {
+			return nil, true, err
+		}
+		fmt.Fprintf(os.Stderr, "📝 Wrote baseline with %d violations to %s\n", len(violations), writeBaselinePath)
+		return violations, true, nil
+	}
+
+	if baselinePath == "" {
+		return violations, false, nil
+	}
+
+	baseline, err := lint.LoadBaseline(baselinePath)
+	if err != nil {
+		return nil, false, err
+	}
+	for file, content := range sources {
+		baseline.SetSource(file, content)
+	}
+
+	return baseline.Filter(violations), false, nil
+}
+
+// reportViolations writes violations using the reporter for the given format,
+// using text for the text format
+func reportViolations(w io.Writer, violations []*lint.Violation, format string, text *textReporter) error {
+	var reporter lint.Reporter
+	if format == "text" {
+		reporter = text
+	} else {
+		var err error
+		reporter, err = lint.NewReporter(format)
+		if err != nil {
+			return err
+		}
+	}
+	return reporter.Report(w, violations)
+}
+
+// textReporter outputs violations as human-readable, optionally colored text
+type textReporter struct {
+	useColor bool
+	// codeFrame shows the source line of each violation with its span underlined
+	codeFrame bool
+	// sources holds file contents by name; other files are read from disk
+	sources map[string]string
+}
+
+// Report implements lint.Reporter
+func (r *textReporter) Report(w io.Writer, violations []*lint.Violation) error {
+	return r.outputTextWithColor(w, violations)
+}
+
+// source returns the content of a file, or "" when it cannot be read
+func (r *textReporter) source(file string) string {
+	if content, ok := r.sources[file]; ok {
+		return content
+	}
+	if r.sources == nil {
+		r.sources = make(map[string]string)
+	}
+	content, _ := os.ReadFile(file)
+	r.sources[file] = string(content)
+	return string(content)
 }
 
 // outputTextWithColor outputs violations with colored text
-func outputTextWithColor(violations []*lint.Violation, useColor bool) error {
+func (r *textReporter) outputTextWithColor(w io.Writer, violations []*lint.Violation) error {
+	useColor := r.useColor
 	if len(violations) == 0 {
 		if useColor {
 			style := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-			fmt.Println(style.Render("✅ No issues found"))
+			fmt.Fprintln(w, style.Render("✅ No issues found"))
 		} else {
-			fmt.Println("✅ No issues found")
+			fmt.Fprintln(w, "✅ No issues found")
 		}
 		return nil
 	}
@@ -178,15 +469,16 @@ func outputTextWithColor(violations []*lint.Violation, useColor bool) error {
 		// Format colored output
 		filePart := fileStyle.Render(fmt.Sprintf("%s:%d:%d:", violation.File, violation.Line, violation.Column))
 
-		var severityPart string
+		var severityStyle lipgloss.Style
 		switch violation.Severity {
 		case lint.SeverityError:
-			severityPart = errorStyle.Render(string(violation.Severity))
+			severityStyle = errorStyle
 		case lint.SeverityWarning:
-			severityPart = warningStyle.Render(string(violation.Severity))
+			severityStyle = warningStyle
 		case lint.SeverityInfo:
-			severityPart = infoStyle.Render(string(violation.Severity))
+			severityStyle = infoStyle
 		}
+		severityPart := severityStyle.Render(string(violation.Severity))
 
 		rulePart := ruleStyle.Render(fmt.Sprintf("[%s]", violation.Rule.ID))
 
@@ -198,7 +490,18 @@ func outputTextWithColor(violations []*lint.Violation, useColor bool) error {
 			result = fmt.Sprintf("%s %s %s %s", filePart, severityPart, rulePart, violation.Message)
 		}
 
-		fmt.Println(result)
+		fmt.Fprintln(w, result)
+
+		if r.codeFrame {
+			fmt.Fprint(w, lint.CodeFrame(r.source(violation.File), violation, func(underline string) string {
+				return severityStyle.Render(underline)
+			}))
+		}
+
+		for _, related := range violation.Related {
+			location := fileStyle.Render(fmt.Sprintf("%s:%d:%d:", related.File, related.Line, related.Column))
+			fmt.Fprintf(w, "    %s note: %s\n", location, related.Message)
+		}
 	}
 
 	// Summary
@@ -217,7 +520,7 @@ func outputTextWithColor(violations []*lint.Violation, useColor bool) error {
 		}
 	}
 
-	fmt.Printf("\n📊 Found %d issues: %d errors, %d warnings, %d info\n",
+	fmt.Fprintf(w, "\n📊 Found %d issues: %d errors, %d warnings, %d info\n",
 		len(violations), errorCount, warningCount, infoCount)
 
 	return nil
@@ -253,29 +556,55 @@ func runLintStdin(cmd *cobra.Command) error {
 	format, _ := cmd.Flags().GetString("format")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	noColor, _ := cmd.Flags().GetBool("no-color")
+	noCodeFrame, _ := cmd.Flags().GetBool("no-code-frame")
 	useAST, _ := cmd.Flags().GetBool("use-ast")
 
+	if fix, _ := cmd.Flags().GetBool("fix"); fix {
+		return fmt.Errorf("--fix cannot be used with --stdin")
+	}
+
+	policy, err := loadFailPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	target, err := loadTarget(cmd)
+	if err != nil {
+		return err
+	}
+
 	// Create linter
 	linter := lint.NewLinterWithAST(rulesDir, useAST)
+	linter.SetTarget(target)
 	err = linter.LoadRules()
 	if err != nil {
 		return fmt.Errorf("failed to load rules: %w", err)
 	}
 
-	// Lint content
+	// Lint content; LintContent recovers from syntax errors, which fail the run
+	if err := lint.CheckSyntax(string(content)); err != nil {
+		return fmt.Errorf("failed to parse content: %w", err)
+	}
 	violations, err := linter.LintContent(string(content), "<stdin>")
 	if err != nil {
 		return fmt.Errorf("failed to lint content: %w", err)
 	}
 
 	// Filter violations
+	policy.overrideSeverities(violations)
 	filteredViolations := filterViolations(violations, disabled, enabledOnly, quiet)
 
+	// Apply or record the baseline
+	sources := map[string]string{"<stdin>": string(content)}
+	filteredViolations, done, err := applyBaseline(cmd, filteredViolations, sources)
+	if err != nil || done {
+		return err
+	}
+
 	// Output results
-	switch format {
-	case "json":
-		return outputJSON(filteredViolations)
-	default:
-		return outputTextWithColor(filteredViolations, !noColor)
+	text := &textReporter{useColor: !noColor, codeFrame: !noCodeFrame, sources: sources}
+	if err := reportViolations(os.Stdout, filteredViolations, format, text); err != nil {
+		return err
 	}
+	return policy.check(filteredViolations)
 }
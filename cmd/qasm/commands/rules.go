@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/orangekame3/qasmtools/highlight"
+	"github.com/orangekame3/qasmtools/lint"
+	"github.com/spf13/cobra"
+)
+
+// NewRulesCommand creates the rules command
+func NewRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "List and explain lint rules",
+		Long:  `Browse the lint rules and their documentation from the terminal.`,
+	}
+
+	cmd.PersistentFlags().String("rules", "", "Rules directory")
+	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List lint rules",
+		Args:  cobra.NoArgs,
+		RunE:  runRulesList,
+	}
+	listCmd.Flags().StringSlice("tag", []string{}, "Only list rules with one of the given tags (comma-separated)")
+	listCmd.Flags().String("severity", "", "Only list rules with the given level (error, warning, info)")
+	listCmd.Flags().Bool("fixable", false, "Only list rules with automatic fixes")
+
+	explainCmd := &cobra.Command{
+		Use:   "explain <rule>",
+		Short: "Explain a lint rule with examples",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rulesDir, _ := cmd.Flags().GetString("rules")
+			noColor, _ := cmd.Flags().GetBool("no-color")
+			return explainRule(cmd.OutOrStdout(), rulesDir, args[0], !noColor)
+		},
+	}
+
+	cmd.AddCommand(listCmd, explainCmd)
+	return cmd
+}
+
+func runRulesList(cmd *cobra.Command, args []string) error {
+	rulesDir, _ := cmd.Flags().GetString("rules")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	tags, _ := cmd.Flags().GetStringSlice("tag")
+	severity, _ := cmd.Flags().GetString("severity")
+	fixable, _ := cmd.Flags().GetBool("fixable")
+
+	if severity != "" && !lint.Severity(severity).Valid() {
+		return fmt.Errorf("invalid severity %q (expected error, warning or info)", severity)
+	}
+
+	rules, err := loadRuleList(rulesDir)
+	if err != nil {
+		return err
+	}
+
+	var listed []*lint.Rule
+	for _, rule := range rules {
+		if severity != "" && rule.Level != lint.Severity(severity) {
+			continue
+		}
+		if fixable && !rule.Fixable {
+			continue
+		}
+		if len(tags) > 0 && !slices.ContainsFunc(tags, func(tag string) bool { return slices.Contains(rule.Tags, tag) }) {
+			continue
+		}
+		listed = append(listed, rule)
+	}
+
+	outputRuleList(cmd.OutOrStdout(), listed, !noColor)
+	return nil
+}
+
+// loadRuleList loads the rules of a rules directory sorted by ID
+func loadRuleList(rulesDir string) ([]*lint.Rule, error) {
+	rules, err := lint.NewRuleLoader(rulesDir).LoadRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rules: %w", err)
+	}
+	slices.SortFunc(rules, func(a, b *lint.Rule) int { return strings.Compare(a.ID, b.ID) })
+	return rules, nil
+}
+
+// ruleStyles holds the styles of the rule list and explanations
+type ruleStyles struct {
+	id, heading, muted lipgloss.Style
+	levels             map[lint.Severity]lipgloss.Style
+}
+
+// newRuleStyles creates the styles, using the lint output colors when useColor is set
+func newRuleStyles(useColor bool) ruleStyles {
+	if !useColor {
+		plain := lipgloss.NewStyle()
+		return ruleStyles{id: plain, heading: plain, muted: plain, levels: map[lint.Severity]lipgloss.Style{}}
+	}
+	return ruleStyles{
+		id:      lipgloss.NewStyle().Foreground(lipgloss.Color("13")).Bold(true), // Magenta
+		heading: lipgloss.NewStyle().Bold(true),
+		muted:   lipgloss.NewStyle().Foreground(lipgloss.Color("8")), // Gray
+		levels: map[lint.Severity]lipgloss.Style{
+			lint.SeverityError:   lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true),  // Red
+			lint.SeverityWarning: lipgloss.NewStyle().Foreground(lipgloss.Color("11")).Bold(true), // Yellow
+			lint.SeverityInfo:    lipgloss.NewStyle().Foreground(lipgloss.Color("14")).Bold(true), // Cyan
+		},
+	}
+}
+
+// level renders a severity padded to the width of the longest one
+func (s ruleStyles) level(severity lint.Severity) string {
+	return s.levels[severity].Render(fmt.Sprintf("%-7s", severity))
+}
+
+// outputRuleList writes one line per rule
+func outputRuleList(w io.Writer, rules []*lint.Rule, useColor bool) {
+	if len(rules) == 0 {
+		fmt.Fprintln(w, "No rules match the given filters")
+		return
+	}
+
+	styles := newRuleStyles(useColor)
+	nameWidth := 0
+	for _, rule := range rules {
+		nameWidth = max(nameWidth, len(rule.Name))
+	}
+
+	for _, rule := range rules {
+		fixable := "  "
+		if rule.Fixable {
+			fixable = "🔧"
+		}
+		fmt.Fprintf(w, "%s %s %s %-*s %s\n", styles.id.Render(rule.ID), styles.level(rule.Level), fixable,
+			nameWidth, rule.Name, styles.muted.Render(rule.Description))
+	}
+	fmt.Fprintf(w, "\n📋 %d rules (🔧 fixable with qasm lint --fix)\n", len(rules))
+}
+
+// explainRule writes the documentation of a rule: its description, tags,
+// highlighted examples and links
+func explainRule(w io.Writer, rulesDir, id string, useColor bool) error {
+	rules, err := loadRuleList(rulesDir)
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(rules, func(rule *lint.Rule) bool { return strings.EqualFold(rule.ID, id) })
+	if index < 0 {
+		return fmt.Errorf("rule %s not found (see qasm rules list)", id)
+	}
+	rule := rules[index]
+	styles := newRuleStyles(useColor)
+
+	fmt.Fprintf(w, "%s %s %s\n\n", styles.id.Render(rule.ID), rule.Name, styles.levels[rule.Level].Render(string(rule.Level)))
+	fmt.Fprintln(w, rule.Description)
+
+	var details []string
+	if len(rule.Tags) > 0 {
+		details = append(details, "Tags: "+strings.Join(rule.Tags, ", "))
+	}
+	if rule.Fixable {
+		details = append(details, "Fixable: yes (qasm lint --fix)")
+	}
+	if len(details) > 0 {
+		fmt.Fprintln(w)
+	}
+	for _, detail := range details {
+		fmt.Fprintln(w, styles.muted.Render(detail))
+	}
+
+	writeExample := func(title, code string) {
+		if strings.TrimSpace(code) == "" {
+			return
+		}
+		fmt.Fprintf(w, "\n%s\n\n", styles.heading.Render(title))
+		code = strings.TrimRight(code, "\n")
+		if useColor {
+			if highlighted, err := highlight.New().Highlight(code); err == nil {
+				code = highlighted
+			}
+		}
+		for _, line := range strings.Split(code, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	writeExample("Incorrect", rule.Examples.Incorrect)
+	writeExample("Correct", rule.Examples.Correct)
+
+	if rule.SpecificationURL != "" || rule.DocumentationURL != "" {
+		fmt.Fprintln(w)
+	}
+	if rule.SpecificationURL != "" {
+		fmt.Fprintf(w, "Specification: %s\n", rule.SpecificationURL)
+	}
+	if rule.DocumentationURL != "" {
+		fmt.Fprintf(w, "Documentation: %s\n", rule.DocumentationURL)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"os"
 
 	"github.com/charmbracelet/fang"
@@ -18,11 +20,24 @@ func main() {
 		RepoName:       "qasmtools",
 	})
 
-	if err := fang.Execute(context.Background(), rootCmd, fang.WithVersion(GetVersion())); err != nil {
-		os.Exit(1)
+	if err := fang.Execute(context.Background(), rootCmd,
+		fang.WithVersion(GetVersion()),
+		fang.WithErrorHandler(handleError),
+	); err != nil {
+		os.Exit(commands.ExitCode(err))
 	}
 }
 
+// handleError prints command errors, except exit errors whose output the
+// command has already written
+func handleError(w io.Writer, styles fang.Styles, err error) {
+	var exitErr *commands.ExitError
+	if errors.As(err, &exitErr) {
+		return
+	}
+	fang.DefaultErrorHandler(w, styles, err)
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "qasm",
 	Short: "QASM CLI tools",
@@ -35,6 +50,7 @@ func init() {
 		commands.NewFormatCommand(),
 		commands.NewHighlightCommand(),
 		commands.NewLintCommand(),
+		commands.NewRulesCommand(),
 		commands.NewParseCommand(),
 		commands.NewBenchmarkCommand(),
 	)
@@ -3,7 +3,10 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/orangekame3/qasmtools/cmd/qasm/commands"
@@ -324,6 +327,147 @@ func TestCheckFileWithConfig(t *testing.T) {
 	}
 }
 
+func TestRulesCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		contains    []string
+		notContains []string
+		expectError bool
+	}{
+		{
+			name:     "list_all",
+			args:     []string{"list", "--no-color"},
+			contains: []string{"QAS001", "QAS004", "QAS022"},
+		},
+		{
+			name:        "list_fixable",
+			args:        []string{"list", "--fixable", "--no-color"},
+			contains:    []string{"QAS020", "QAS021", "QAS022", "3 rules"},
+			notContains: []string{"QAS004"},
+		},
+		{
+			name:        "list_by_tag_and_severity",
+			args:        []string{"list", "--tag", "naming,style", "--severity", "error", "--no-color"},
+			contains:    []string{"QAS011"},
+			notContains: []string{"QAS005", "QAS012"},
+		},
+		{
+			name:        "list_invalid_severity",
+			args:        []string{"list", "--severity", "fatal"},
+			expectError: true,
+		},
+		{
+			name: "explain",
+			args: []string{"explain", "qas004", "--no-color"},
+			contains: []string{
+				"QAS004 out-of-bounds-index error",
+				"Incorrect",
+				"    h q[2];",
+				"Specification: https://openqasm.com/",
+			},
+		},
+		{
+			name:        "explain_unknown_rule",
+			args:        []string{"explain", "QAS999"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := commands.NewRulesCommand()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Execute() error = %v, expectError %v", err, tt.expectError)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(out.String(), unwanted) {
+					t.Errorf("output contains %q:\n%s", unwanted, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestLintExplain(t *testing.T) {
+	cmd := commands.NewLintCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--explain", "QAS009", "--no-color"})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "QAS009 illegal-break-continue error") {
+		t.Errorf("unexpected explanation:\n%s", out.String())
+	}
+}
+
+func TestLintExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "circuit.qasm")
+	content := "OPENQASM 3.0;\ninclude \"stdgates.inc\";\nqubit[2] myQubits;\nh myQubits[0];\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.qasm")
+	if err := os.WriteFile(invalid, []byte("OPENQASM 3.0;\nqubit[2] q;\nh q[0]\ncx q[0], ;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "warnings_fail_by_default", args: nil, wantCode: commands.ExitViolations},
+		{name: "fail_on_error", args: []string{"--fail-on", "error"}, wantCode: commands.ExitOK},
+		{name: "max_warnings_exceeded", args: []string{"--fail-on", "error", "--max-warnings", "1"}, wantCode: commands.ExitViolations},
+		{name: "max_warnings_reached", args: []string{"--fail-on", "error", "--max-warnings", "2"}, wantCode: commands.ExitOK},
+		{name: "severity_override", args: []string{"--fail-on", "error", "--severity", "QAS005=error"}, wantCode: commands.ExitViolations},
+		{name: "severity_override_below_fail_on", args: []string{"--fail-on", "warning", "--severity", "QAS005=info,QAS012=info"}, wantCode: commands.ExitOK},
+		{name: "quiet_hides_warnings", args: []string{"--quiet"}, wantCode: commands.ExitOK},
+		{name: "invalid_severity", args: []string{"--severity", "QAS005"}, wantCode: commands.ExitFailure},
+		{name: "invalid_fail_on", args: []string{"--fail-on", "fatal"}, wantCode: commands.ExitFailure},
+		{name: "missing_file", args: []string{filepath.Join(t.TempDir(), "missing.qasm")}, wantCode: commands.ExitFailure},
+		{name: "syntax_error", args: []string{invalid}, wantCode: commands.ExitFailure},
+	}
+
+	// Discard the report written to stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := commands.NewLintCommand()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append([]string{"--no-color", "--parallel=false", file}, tt.args...))
+
+			if code := commands.ExitCode(cmd.Execute()); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
+
 // Helper function to convert various types to string for flag setting
 func toString(value interface{}) string {
 	switch v := value.(type) {
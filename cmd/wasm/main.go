@@ -9,6 +9,7 @@ import (
 	"github.com/orangekame3/qasmtools/formatter"
 	"github.com/orangekame3/qasmtools/highlight"
 	"github.com/orangekame3/qasmtools/lint"
+	"github.com/orangekame3/qasmtools/parser"
 )
 
 // Global variables
@@ -265,9 +266,44 @@ func lintQASM(this js.Value, args []js.Value) (result interface{}) {
 		jsViolation.Set("file", "<stdin>") // Always use <stdin> for consistency
 		jsViolation.Set("line", v.Line)
 		jsViolation.Set("column", v.Column)
-		jsViolation.Set("severity", "error") // すべての違反をerrorとして扱う
+		jsViolation.Set("end_line", v.EndLine)
+		jsViolation.Set("end_column", v.EndColumn)
+		jsViolation.Set("severity", string(v.Severity))
 		jsViolation.Set("rule_id", v.Rule.ID)
 		jsViolation.Set("message", v.Message)
+
+		jsViolationTags := js.Global().Get("Array").New(len(v.Tags))
+		for i, tag := range v.Tags {
+			jsViolationTags.SetIndex(i, string(tag))
+		}
+		jsViolation.Set("tags", jsViolationTags)
+
+		jsRelated := js.Global().Get("Array").New(len(v.Related))
+		for i, related := range v.Related {
+			jsLocation := js.Global().Get("Object").New()
+			jsLocation.Set("file", related.File)
+			jsLocation.Set("line", related.Line)
+			jsLocation.Set("column", related.Column)
+			jsLocation.Set("message", related.Message)
+			jsRelated.SetIndex(i, jsLocation)
+		}
+		jsViolation.Set("related", jsRelated)
+
+		// Fix edits apply to the formatted code the violations refer to
+		if v.Fix != nil {
+			jsFix := js.Global().Get("Object").New()
+			jsFix.Set("description", v.Fix.Description)
+			jsEdits := js.Global().Get("Array").New(len(v.Fix.Edits))
+			for i, edit := range v.Fix.Edits {
+				jsEdit := js.Global().Get("Object").New()
+				jsEdit.Set("start", jsPosition(edit.Start))
+				jsEdit.Set("end", jsPosition(edit.End))
+				jsEdit.Set("new_text", edit.NewText)
+				jsEdits.SetIndex(i, jsEdit)
+			}
+			jsFix.Set("edits", jsEdits)
+			jsViolation.Set("fix", jsFix)
+		}
 		jsViolation.Set("documentation_url", fmt.Sprintf("https://github.com/orangekame3/qasmtools/blob/main/docs/rules/%s.md", v.Rule.ID))
 
 		// Add rule details
@@ -298,12 +334,26 @@ func lintQASM(this js.Value, args []js.Value) (result interface{}) {
 	jsResult.Set("violations", jsViolations)
 
 	jsSummary := js.Global().Get("Object").New()
+	counts := make(map[lint.Severity]int)
+	for _, v := range violations {
+		counts[v.Severity]++
+	}
 	jsSummary.Set("total", len(violations))
-	jsSummary.Set("errors", len(violations)) // すべての違反をエラーとして扱う
-	jsSummary.Set("warnings", 0)
-	jsSummary.Set("info", 0)
+	jsSummary.Set("errors", counts[lint.SeverityError])
+	jsSummary.Set("warnings", counts[lint.SeverityWarning])
+	jsSummary.Set("info", counts[lint.SeverityInfo])
 	jsResult.Set("summary", jsSummary)
 
 	result = jsResult
 	return result
 }
+
+// jsPosition converts a source position to a JavaScript object. The offset
+// counts characters (runes) from the start of the code.
+func jsPosition(pos parser.Position) js.Value {
+	jsPos := js.Global().Get("Object").New()
+	jsPos.Set("line", pos.Line)
+	jsPos.Set("column", pos.Column)
+	jsPos.Set("offset", pos.Offset)
+	return jsPos
+}
@@ -0,0 +1,252 @@
+// Package discover finds the QASM files named by command line arguments for
+// the lint, fmt and benchmark commands. Arguments may be files, directories,
+// recursive directory patterns ending in "/..." and glob patterns. Files found
+// in directories are filtered by extension, --exclude patterns and the
+// .gitignore and .qasmignore files of the project.
+package discover
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultExtensions are the extensions of the files found in directories
+var DefaultExtensions = []string{".qasm", ".inc"}
+
+// IgnoreFiles are the names of the files holding gitignore-style patterns of
+// paths to skip. They apply to their own directory and everything below it.
+var IgnoreFiles = []string{".gitignore", ".qasmignore"}
+
+// Options controls which files are found
+type Options struct {
+	// Extensions of the files found in directories; DefaultExtensions when empty
+	Extensions []string
+	// Match is a glob the name of files found in directories must match. It is
+	// used instead of Extensions when set.
+	Match string
+	// Exclude holds gitignore-style patterns, relative to the working
+	// directory, of paths to skip
+	Exclude []string
+	// NoIgnoreFiles disables .gitignore and .qasmignore files
+	NoIgnoreFiles bool
+}
+
+// finder walks directories for one call of Files
+type finder struct {
+	opts    Options
+	exclude ignoreList
+	found   map[string]string // file paths as reported, keyed by their clean form
+}
+
+// Files returns the files named by args, sorted and without duplicates:
+//
+//	circuit.qasm   the file itself, whatever its extension or ignore status
+//	lib            the files directly in the directory
+//	lib/...        the files in the directory and all its subdirectories
+//	"src/**/*.qasm" the files matching the glob
+//
+// Files found through directories and globs are skipped when an exclude
+// pattern or ignore file matches them; files found through directories must
+// also have one of the extensions.
+func Files(args []string, opts Options) ([]string, error) {
+	if len(opts.Extensions) == 0 {
+		opts.Extensions = DefaultExtensions
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	f := &finder{opts: opts, found: make(map[string]string)}
+	for _, pattern := range opts.Exclude {
+		if rule, ok := parseIgnoreLine(cwd, pattern); ok {
+			f.exclude = append(f.exclude, rule)
+		}
+	}
+
+	for _, arg := range args {
+		if err := f.add(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	files := make([]string, 0, len(f.found))
+	for _, file := range f.found {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// add adds the files named by one argument
+func (f *finder) add(arg string) error {
+	if arg == "..." || strings.HasSuffix(arg, "/...") {
+		dir := strings.TrimSuffix(strings.TrimSuffix(arg, "..."), "/")
+		if dir == "" {
+			dir = "."
+		}
+		return f.walkDir(dir, -1, f.hasExtension)
+	}
+
+	if strings.ContainsAny(arg, "*?[") {
+		return f.addGlob(arg)
+	}
+
+	info, err := os.Stat(arg)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return f.walkDir(arg, 0, f.hasExtension)
+	}
+	f.addFile(arg)
+	return nil
+}
+
+// addGlob adds the files matching a glob, walking from the directory before
+// its first wildcard
+func (f *finder) addGlob(glob string) error {
+	glob = filepath.ToSlash(filepath.Clean(glob))
+	pattern, err := regexp.Compile("^" + globToRegexp(glob) + "$")
+	if err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", glob, err)
+	}
+
+	root := "."
+	if i := strings.LastIndex(glob[:strings.IndexAny(glob, "*?[")], "/"); i >= 0 {
+		root = glob[:i]
+		if root == "" {
+			root = "/"
+		}
+	}
+
+	// Without "**" the glob cannot match below its own number of directories
+	depth := -1
+	if !strings.Contains(glob, "**") {
+		depth = strings.Count(glob, "/") - strings.Count(strings.TrimSuffix(root, "/"), "/")
+		if root == "." {
+			depth = strings.Count(glob, "/")
+		} else {
+			depth--
+		}
+	}
+
+	return f.walkDir(root, depth, func(path string) bool {
+		return pattern.MatchString(filepath.ToSlash(path))
+	})
+}
+
+// walkDir adds the files below root accepted by match, descending at most
+// depth directory levels (without limit when depth is negative)
+func (f *finder) walkDir(root string, depth int, match func(string) bool) error {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", root)
+	}
+
+	var rules ignoreList
+	if !f.opts.NoIgnoreFiles {
+		for _, dir := range projectAncestors(abs) {
+			rules = f.readIgnoreFiles(rules, dir)
+		}
+	}
+	return f.walk(root, abs, rules, depth, match)
+}
+
+// walk adds the files of one directory and descends into its subdirectories
+func (f *finder) walk(dir, abs string, rules ignoreList, depth int, match func(string) bool) error {
+	if !f.opts.NoIgnoreFiles {
+		rules = f.readIgnoreFiles(rules, abs)
+	}
+
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		absPath := filepath.Join(abs, entry.Name())
+		isDir := entry.IsDir()
+
+		if isDir && entry.Name() == ".git" {
+			continue
+		}
+		if f.exclude.ignored(absPath, isDir) || rules.ignored(absPath, isDir) {
+			continue
+		}
+
+		if isDir {
+			if depth != 0 {
+				if err := f.walk(path, absPath, rules, depth-1, match); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if entry.Type().IsRegular() && match(path) {
+			f.addFile(path)
+		}
+	}
+	return nil
+}
+
+// addFile records a file unless the same file was already found
+func (f *finder) addFile(path string) {
+	if _, exists := f.found[filepath.Clean(path)]; !exists {
+		f.found[filepath.Clean(path)] = path
+	}
+}
+
+// readIgnoreFiles appends the rules of the ignore files in dir
+func (f *finder) readIgnoreFiles(rules ignoreList, dir string) ignoreList {
+	for _, name := range IgnoreFiles {
+		rules = rules.readIgnoreFile(dir, name)
+	}
+	return rules
+}
+
+// hasExtension reports whether a file found in a directory is wanted
+func (f *finder) hasExtension(path string) bool {
+	name := filepath.Base(path)
+	if f.opts.Match != "" {
+		matched, _ := filepath.Match(f.opts.Match, name)
+		return matched
+	}
+	for _, ext := range f.opts.Extensions {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// projectAncestors returns the directories above dir up to the root of the git
+// repository containing it, outermost first. Ignore files outside a repository
+// do not apply, so nothing is returned when dir is not in one.
+func projectAncestors(dir string) []string {
+	var ancestors []string
+	for current := filepath.Dir(dir); ; current = filepath.Dir(current) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			// dir is itself the repository root
+			return nil
+		}
+		ancestors = append([]string{current}, ancestors...)
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return ancestors
+		}
+		if filepath.Dir(current) == current {
+			return nil
+		}
+	}
+}
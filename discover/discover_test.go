@@ -0,0 +1,153 @@
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// createTree creates files with the given contents below dir
+func createTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	createTree(t, dir, map[string]string{
+		".git/HEAD":         "",
+		".gitignore":        "build/\nskip.qasm\n",
+		"a.qasm":            "",
+		"notes.txt":         "",
+		"build/out.qasm":    "",
+		"lib/gates.inc":     "",
+		"src/x.qasm":        "",
+		"src/skip.qasm":     "",
+		"src/sub/y.qasm":    "",
+		"keep/.qasmignore":  "!skip.qasm\n",
+		"keep/skip.qasm":    "",
+		"keep/deep/z.QASM":  "",
+		"keep/deep/z.qasm~": "",
+	})
+	t.Chdir(dir)
+
+	tests := []struct {
+		name     string
+		args     []string
+		opts     Options
+		expected []string
+	}{
+		{
+			name:     "recursive",
+			args:     []string{"./..."},
+			expected: []string{"a.qasm", "keep/deep/z.QASM", "keep/skip.qasm", "lib/gates.inc", "src/sub/y.qasm", "src/x.qasm"},
+		},
+		{
+			name:     "directory without recursion",
+			args:     []string{"src"},
+			expected: []string{"src/x.qasm"},
+		},
+		{
+			name:     "ignore files of parent directories apply",
+			args:     []string{"src/..."},
+			expected: []string{"src/sub/y.qasm", "src/x.qasm"},
+		},
+		{
+			name:     "explicit files are always kept",
+			args:     []string{"./src/skip.qasm", "notes.txt", "src/skip.qasm"},
+			expected: []string{"./src/skip.qasm", "notes.txt"},
+		},
+		{
+			name:     "glob with double star",
+			args:     []string{"src/**/*.qasm"},
+			expected: []string{"src/sub/y.qasm", "src/x.qasm"},
+		},
+		{
+			name:     "glob limited to its depth",
+			args:     []string{"*/*.qasm"},
+			expected: []string{"keep/skip.qasm", "src/x.qasm"},
+		},
+		{
+			name:     "exclude patterns",
+			args:     []string{"./..."},
+			opts:     Options{Exclude: []string{"sub/", "/lib/*.inc", "keep"}},
+			expected: []string{"a.qasm", "src/x.qasm"},
+		},
+		{
+			name:     "without ignore files",
+			args:     []string{"build", "src"},
+			opts:     Options{NoIgnoreFiles: true},
+			expected: []string{"build/out.qasm", "src/skip.qasm", "src/x.qasm"},
+		},
+		{
+			name:     "extensions",
+			args:     []string{"./..."},
+			opts:     Options{Extensions: []string{".inc"}},
+			expected: []string{"lib/gates.inc"},
+		},
+		{
+			name:     "name pattern",
+			args:     []string{"./..."},
+			opts:     Options{Match: "[xy].qasm"},
+			expected: []string{"src/sub/y.qasm", "src/x.qasm"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := Files(tt.args, tt.opts)
+			if err != nil {
+				t.Fatalf("Files() error = %v", err)
+			}
+			for i := range files {
+				files[i] = filepath.ToSlash(files[i])
+			}
+			if !reflect.DeepEqual(files, tt.expected) {
+				t.Errorf("Files() = %v, want %v", files, tt.expected)
+			}
+		})
+	}
+
+	if _, err := Files([]string{"missing.qasm"}, Options{}); err == nil {
+		t.Error("Files() expected an error for a missing file")
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := []struct {
+		glob    string
+		path    string
+		matches bool
+	}{
+		{"*.qasm", "a.qasm", true},
+		{"*.qasm", "dir/a.qasm", false},
+		{"**/*.qasm", "a.qasm", true},
+		{"**/*.qasm", "dir/sub/a.qasm", true},
+		{"dir/**", "dir/sub/a.qasm", true},
+		{"a?.inc", "ab.inc", true},
+		{"[!a]*.inc", "a.inc", false},
+		{"[!a]*.inc", "b.inc", true},
+		{`\*.qasm`, "*.qasm", true},
+		{"a+b.qasm", "a+b.qasm", true},
+	}
+
+	for _, tt := range tests {
+		parsed, ok := parseIgnoreLine("/", "/"+tt.glob)
+		if !ok {
+			t.Fatalf("parseIgnoreLine(%q) failed", tt.glob)
+		}
+		if got := parsed.pattern.MatchString(tt.path); got != tt.matches {
+			t.Errorf("glob %q matching %q = %v, want %v", tt.glob, tt.path, got, tt.matches)
+		}
+	}
+}
@@ -153,6 +153,15 @@ func (f *Formatter) joinWithNewline(lines []string) string {
 
 // formatQuantumDeclarationAST formats quantum declarations using pure AST approach
 func (f *Formatter) formatQuantumDeclarationAST(stmt *parser.QuantumDeclaration, indent int) string {
+	// OpenQASM 2 registers put the size after the identifier
+	if stmt.Type == "qreg" {
+		result := f.indent(indent) + stmt.Type + " " + stmt.Identifier
+		if stmt.Size != nil {
+			result += "[" + f.formatExpressionAST(stmt.Size) + "]"
+		}
+		return result + ";"
+	}
+
 	result := f.indent(indent) + stmt.Type
 
 	if stmt.Size != nil {
@@ -166,6 +175,15 @@ func (f *Formatter) formatQuantumDeclarationAST(stmt *parser.QuantumDeclaration,
 
 // formatClassicalDeclarationAST formats classical declarations using pure AST approach
 func (f *Formatter) formatClassicalDeclarationAST(stmt *parser.ClassicalDeclaration, indent int) string {
+	// OpenQASM 2 registers put the size after the identifier
+	if stmt.Type == "creg" {
+		result := f.indent(indent) + stmt.Type + " " + stmt.Identifier
+		if stmt.Size != nil {
+			result += "[" + f.formatExpressionAST(stmt.Size) + "]"
+		}
+		return result + ";"
+	}
+
 	result := f.indent(indent) + stmt.Type
 
 	if stmt.Size != nil {
@@ -185,7 +203,14 @@ func (f *Formatter) formatClassicalDeclarationAST(stmt *parser.ClassicalDeclarat
 
 // formatGateCallAST formats gate calls using pure AST approach
 func (f *Formatter) formatGateCallAST(stmt *parser.GateCall, indent int) string {
-	result := f.indent(indent) + stmt.Name
+	result := f.indent(indent)
+
+	// Add modifiers (inv @, ctrl(2) @, ...)
+	for _, modifier := range stmt.Modifiers {
+		result += f.formatModifierAST(&modifier) + " @ "
+	}
+
+	result += stmt.Name
 
 	// Add parameters if present (with proper spacing)
 	if len(stmt.Parameters) > 0 {
@@ -209,6 +234,19 @@ func (f *Formatter) formatGateCallAST(stmt *parser.GateCall, indent int) string
 	return result
 }
 
+// formatModifierAST formats a gate modifier without the trailing @
+func (f *Formatter) formatModifierAST(modifier *parser.Modifier) string {
+	if len(modifier.Parameters) == 0 {
+		return modifier.Type
+	}
+
+	params := make([]string, len(modifier.Parameters))
+	for i, param := range modifier.Parameters {
+		params[i] = f.formatExpressionAST(param)
+	}
+	return modifier.Type + "(" + strings.Join(params, ", ") + ")"
+}
+
 // formatMeasurementAST formats measurements using pure AST approach
 func (f *Formatter) formatMeasurementAST(stmt *parser.Measurement, indent int) string {
 	result := f.indent(indent) + "measure " + f.formatExpressionAST(stmt.Qubit)
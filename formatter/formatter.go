@@ -140,18 +140,44 @@ func (f *Formatter) hasIncompleteParsingIndicators(content string, program *pars
 		}
 	}
 
-	// Check for gate parameters that might be lost
+	// Check for gate parameters; the text formatter keeps parameter expressions as written
 	if strings.Contains(content, "(") && strings.Contains(content, ")") {
 		for _, stmt := range program.Statements {
 			if gateCall, ok := stmt.(*parser.GateCall); ok {
-				// Check if input had parameters but AST doesn't
-				if f.inputLineHadParameters(content, gateCall.Name) && len(gateCall.Parameters) == 0 {
+				if f.inputLineHadParameters(content, gateCall.Name) {
 					return true
 				}
 			}
 		}
 	}
 
+	// Check for statements the AST formatter cannot render yet
+	if f.hasStatementsWithoutASTFormatting(program.Statements) {
+		return true
+	}
+
+	return false
+}
+
+// hasStatementsWithoutASTFormatting reports statements that would be dropped by AST formatting
+func (f *Formatter) hasStatementsWithoutASTFormatting(statements []parser.Statement) bool {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.QuantumDeclaration, *parser.ClassicalDeclaration, *parser.GateCall,
+			*parser.Measurement, *parser.Include:
+			continue
+		case *parser.GateDefinition:
+			if f.hasStatementsWithoutASTFormatting(s.Body) {
+				return true
+			}
+		case *parser.IfStatement:
+			if f.hasStatementsWithoutASTFormatting(s.ThenBody) || f.hasStatementsWithoutASTFormatting(s.ElseBody) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
 	return false
 }
 
@@ -270,6 +296,15 @@ func (f *Formatter) formatStatementContent(stmt parser.Statement, indent int, pr
 }
 
 func (f *Formatter) formatQuantumDeclaration(stmt *parser.QuantumDeclaration, indent int) string {
+	// OpenQASM 2 registers put the size after the identifier
+	if stmt.Type == "qreg" {
+		result := f.indent(indent) + stmt.Type + " " + stmt.Identifier
+		if stmt.Size != nil {
+			result += "[" + f.formatExpression(stmt.Size) + "]"
+		}
+		return result + ";"
+	}
+
 	result := f.indent(indent) + stmt.Type
 
 	if stmt.Size != nil {
@@ -282,6 +317,15 @@ func (f *Formatter) formatQuantumDeclaration(stmt *parser.QuantumDeclaration, in
 }
 
 func (f *Formatter) formatClassicalDeclaration(stmt *parser.ClassicalDeclaration, indent int) string {
+	// OpenQASM 2 registers put the size after the identifier
+	if stmt.Type == "creg" {
+		result := f.indent(indent) + stmt.Type + " " + stmt.Identifier
+		if stmt.Size != nil {
+			result += "[" + f.formatExpression(stmt.Size) + "]"
+		}
+		return result + ";"
+	}
+
 	result := f.indent(indent) + stmt.Type
 
 	if stmt.Size != nil {
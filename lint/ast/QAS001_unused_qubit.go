@@ -29,7 +29,7 @@ func (r *UnusedQubitRule) CheckAST(program *parser.Program, ctx *CheckContext) [
 
 	// Check each qubit declaration for usage
 	for _, qubitDecl := range declarations.Quantum {
-		if qubitDecl.Type != "qubit" {
+		if qubitDecl.Type != "qubit" && qubitDecl.Type != "qreg" {
 			continue
 		}
 
@@ -40,6 +40,7 @@ func (r *UnusedQubitRule) CheckAST(program *parser.Program, ctx *CheckContext) [
 				WithFile(ctx.File).
 				WithNode(qubitDecl).
 				WithNodeName(qubitDecl.Identifier).
+				WithTag(TagUnnecessary).
 				AsWarning().
 				Build()
 			violations = append(violations, violation)
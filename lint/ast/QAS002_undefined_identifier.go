@@ -42,6 +42,16 @@ func (r *UndefinedIdentifierRule) CheckAST(program *parser.Program, ctx *CheckCo
 	for _, decl := range declarations.Gates {
 		declaredIdentifiers[decl.Name] = true
 	}
+
+	// Add alias identifiers (let)
+	for _, decl := range declarations.Aliases {
+		declaredIdentifiers[decl.Identifier] = true
+	}
+
+	// Add subroutine identifiers (def)
+	for _, decl := range declarations.Subroutines {
+		declaredIdentifiers[decl.Name] = true
+	}
 	
 	// Fallback: extract gate definitions from text if AST parsing missed them
 	if len(declarations.Gates) == 0 {
@@ -60,11 +70,67 @@ func (r *UndefinedIdentifierRule) CheckAST(program *parser.Program, ctx *CheckCo
 	return violations
 }
 
+// gateScopes maps each node inside a gate or subroutine body to its local identifiers
+func (r *UndefinedIdentifierRule) gateScopes(program *parser.Program) map[parser.Node]map[string]bool {
+	scopes := make(map[parser.Node]map[string]bool)
+
+	for _, gateDef := range astutil.FindNodesByType(program, (*parser.GateDefinition)(nil)) {
+		local := make(map[string]bool)
+		for _, param := range gateDef.Parameters {
+			local[param.Name] = true
+		}
+		for _, qubit := range gateDef.Qubits {
+			local[qubit.Name] = true
+		}
+
+		for _, stmt := range gateDef.Body {
+			astutil.VisitAllNodes(stmt, func(node parser.Node) {
+				scopes[node] = local
+			})
+		}
+	}
+
+	for _, subroutine := range astutil.FindNodesByType(program, (*parser.SubroutineDefinition)(nil)) {
+		local := make(map[string]bool)
+		for _, param := range subroutine.Parameters {
+			local[param.Name] = true
+		}
+
+		for _, stmt := range subroutine.Body {
+			astutil.VisitAllNodes(stmt, func(node parser.Node) {
+				scopes[node] = local
+			})
+		}
+	}
+
+	return scopes
+}
+
+// mergeScopes returns a new identifier set containing both scopes
+func (r *UndefinedIdentifierRule) mergeScopes(outer, inner map[string]bool) map[string]bool {
+	merged := make(map[string]bool, len(outer)+len(inner))
+	for name := range outer {
+		merged[name] = true
+	}
+	for name := range inner {
+		merged[name] = true
+	}
+	return merged
+}
+
 // checkIdentifierUsages traverses the AST and checks all identifier usages
 func (r *UndefinedIdentifierRule) checkIdentifierUsages(program *parser.Program, declaredIdentifiers map[string]bool, ctx *CheckContext, violations *[]*Violation) {
+	scopes := r.gateScopes(program)
+
 	astutil.VisitAllNodes(program, func(node parser.Node) {
 		switch n := node.(type) {
 		case *parser.GateCall:
+			// Gate and subroutine parameters are declared inside their bodies
+			declaredIdentifiers := declaredIdentifiers
+			if local, inGate := scopes[n]; inGate {
+				declaredIdentifiers = r.mergeScopes(declaredIdentifiers, local)
+			}
+
 			// Check gate name
 			if n.Name != "" && !declaredIdentifiers[n.Name] && !r.isKeyword(n.Name) {
 				violation := r.NewViolationBuilder().
@@ -133,6 +199,10 @@ func (r *UndefinedIdentifierRule) checkIdentifierUsages(program *parser.Program,
 func (r *UndefinedIdentifierRule) extractIdentifierName(expr parser.Expression) string {
 	switch e := expr.(type) {
 	case *parser.Identifier:
+		// Physical qubits ($0, $1, ...) are never declared
+		if strings.HasPrefix(e.Name, "$") {
+			return ""
+		}
 		return e.Name
 	case *parser.IndexedIdentifier:
 		return e.Name
@@ -93,5 +93,11 @@ func (r *QAS008QubitDeclaredInLocalScopeRule) checkStatementForLocalQubitDeclara
 		if s.Body != nil {
 			r.checkStatementsForLocalQubitDeclarations(s.Body, true, ctx, violations)
 		}
+
+	case *parser.BoxStatement:
+		// Box blocks create local scopes
+		if s.Body != nil {
+			r.checkStatementsForLocalQubitDeclarations(s.Body, true, ctx, violations)
+		}
 	}
 }
\ No newline at end of file
@@ -1,14 +1,13 @@
 package ast
 
 import (
-	"regexp"
-	"strings"
-
 	"github.com/orangekame3/qasmtools/parser"
 )
 
-// QAS009IllegalBreakContinueRule implements QAS009 using text-based analysis within AST package
-// This rule uses text-based analysis since BreakStatement/ContinueStatement are not in current AST
+// QAS009IllegalBreakContinueRule implements QAS009 using AST-based analysis.
+// It walks the statement tree tracking whether a for or while loop encloses
+// each break and continue statement. Subroutine and gate bodies start outside
+// any loop, since control cannot leave a call through break or continue.
 type QAS009IllegalBreakContinueRule struct {
 	*ASTRuleBase
 }
@@ -20,129 +19,50 @@ func NewQAS009IllegalBreakContinueRule() *QAS009IllegalBreakContinueRule {
 	}
 }
 
-// CheckAST performs text-based analysis within AST rule framework
+// CheckAST reports break and continue statements outside of loops
 func (r *QAS009IllegalBreakContinueRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
 	var violations []*Violation
-
-	// Use text-based analysis on the content since break/continue are not in AST
-	content := ctx.Content
-	lines := strings.Split(content, "\n")
-
-	// Track loop nesting depth and find break/continue statements
-	loopDepth := 0
-
-	for i, line := range lines {
-		// Skip comments and empty lines
-		if r.skipCommentAndEmptyLine(line) {
-			continue
-		}
-
-		// Remove comments
-		codeOnly := r.removeComments(line)
-
-		// Track loop nesting changes
-		loopDepth += r.countLoopChanges(codeOnly)
-
-		// Find break/continue statements in this line
-		breakContinueStatements := r.findBreakContinueStatements(codeOnly)
-
-		for _, stmt := range breakContinueStatements {
-			// Check if we're outside of any loop
-			if loopDepth <= 0 {
-				violation := r.NewViolationBuilder().
-					WithMessage("'"+stmt.keyword+"' cannot be used outside of a loop.").
-					WithFile(ctx.File).
-					WithLine(i+1).
-					WithColumn(stmt.column).
-					WithNodeName(stmt.keyword).
-					AsError().
-					Build()
-				violations = append(violations, violation)
-			}
-		}
-
-		// Update loop depth after processing the line (for closing braces)
-		loopDepth += r.countLoopEndChanges(codeOnly)
-	}
-
+	r.checkBlock(program.Statements, false, ctx, &violations)
 	return violations
 }
 
-type breakContinueStatement struct {
-	keyword string
-	column  int
-}
-
-// skipCommentAndEmptyLine checks if a line should be skipped
-func (r *QAS009IllegalBreakContinueRule) skipCommentAndEmptyLine(line string) bool {
-	trimmed := strings.TrimSpace(line)
-	return trimmed == "" || strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "/*")
-}
-
-// removeComments removes comments from a line
-func (r *QAS009IllegalBreakContinueRule) removeComments(line string) string {
-	// Remove single-line comments
-	if idx := strings.Index(line, "//"); idx != -1 {
-		line = line[:idx]
-	}
-	return strings.TrimSpace(line)
-}
-
-// countLoopChanges counts the increase in loop depth for a line (opening loops)
-func (r *QAS009IllegalBreakContinueRule) countLoopChanges(line string) int {
-	loopCount := 0
-
-	// Patterns for loop starts
-	patterns := []*regexp.Regexp{
-		// for loops: for variable in range
-		regexp.MustCompile(`\bfor\s+[a-zA-Z_][a-zA-Z0-9_]*\s+in\s+`),
-		// while loops: while condition
-		regexp.MustCompile(`\bwhile\s*\(`),
-	}
-
-	for _, pattern := range patterns {
-		matches := pattern.FindAllStringSubmatch(line, -1)
-		loopCount += len(matches)
-	}
-
-	return loopCount
-}
-
-// countLoopEndChanges counts the decrease in loop depth for a line (closing braces)
-func (r *QAS009IllegalBreakContinueRule) countLoopEndChanges(line string) int {
-	// Count closing braces that end loops
-	closeBraces := strings.Count(line, "}")
-	return -closeBraces
-}
-
-// findBreakContinueStatements finds all break/continue statements in a line
-func (r *QAS009IllegalBreakContinueRule) findBreakContinueStatements(line string) []breakContinueStatement {
-	var statements []breakContinueStatement
-
-	// Patterns for break and continue statements
-	patterns := []*regexp.Regexp{
-		// break; statement
-		regexp.MustCompile(`\b(break)\s*;`),
-		// continue; statement
-		regexp.MustCompile(`\b(continue)\s*;`),
-	}
-
-	for _, pattern := range patterns {
-		matches := pattern.FindAllStringSubmatch(line, -1)
-		indices := pattern.FindAllStringIndex(line, -1)
-
-		for i, match := range matches {
-			if len(match) >= 2 {
-				keyword := match[1]
-				column := indices[i][0] + 1 // Convert to 1-based indexing
-
-				statements = append(statements, breakContinueStatement{
-					keyword: keyword,
-					column:  column,
-				})
+// checkBlock checks the statements of a block; inLoop tells whether a loop
+// encloses the block
+func (r *QAS009IllegalBreakContinueRule) checkBlock(statements []parser.Statement, inLoop bool, ctx *CheckContext, violations *[]*Violation) {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.BreakStatement:
+			if !inLoop {
+				*violations = append(*violations, r.newViolation(s, "break", ctx))
+			}
+		case *parser.ContinueStatement:
+			if !inLoop {
+				*violations = append(*violations, r.newViolation(s, "continue", ctx))
 			}
+		case *parser.ForStatement:
+			r.checkBlock(s.Body, true, ctx, violations)
+		case *parser.WhileStatement:
+			r.checkBlock(s.Body, true, ctx, violations)
+		case *parser.IfStatement:
+			r.checkBlock(s.ThenBody, inLoop, ctx, violations)
+			r.checkBlock(s.ElseBody, inLoop, ctx, violations)
+		case *parser.BoxStatement:
+			r.checkBlock(s.Body, inLoop, ctx, violations)
+		case *parser.SubroutineDefinition:
+			r.checkBlock(s.Body, false, ctx, violations)
+		case *parser.GateDefinition:
+			r.checkBlock(s.Body, false, ctx, violations)
 		}
 	}
+}
 
-	return statements
-}
\ No newline at end of file
+// newViolation creates the violation for a misplaced break or continue
+func (r *QAS009IllegalBreakContinueRule) newViolation(stmt parser.Statement, keyword string, ctx *CheckContext) *Violation {
+	return r.NewViolationBuilder().
+		WithMessage("'" + keyword + "' cannot be used outside of a loop.").
+		WithFile(ctx.File).
+		WithNode(stmt).
+		WithNodeName(keyword).
+		AsError().
+		Build()
+}
@@ -0,0 +1,541 @@
+package ast
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// QubitUsedAfterMeasurementRule implements QAS013 using AST-based analysis.
+// It tracks which qubits have been measured through the statement sequence
+// and reports gates applied to them before an intervening reset.
+type QubitUsedAfterMeasurementRule struct {
+	*ASTRuleBase
+}
+
+// NewQubitUsedAfterMeasurementRule creates a new AST-based qubit used after measurement rule
+func NewQubitUsedAfterMeasurementRule() ASTRule {
+	return &QubitUsedAfterMeasurementRule{
+		ASTRuleBase: NewASTRuleBase("QAS013"),
+	}
+}
+
+// measuredRegister tracks the measurement state of a single register
+type measuredRegister struct {
+	all     bool           // The whole register was measured
+	except  map[int]bool   // Elements reset after a whole-register measurement
+	indices map[int]string // Individually measured elements and their classical target
+	target  string         // Classical target of a whole-register measurement
+}
+
+// measurementState maps register names to their measurement state
+type measurementState map[string]*measuredRegister
+
+// measurementAnalysis holds the state shared while walking the program
+type measurementAnalysis struct {
+	ctx        *CheckContext
+	violations []*Violation
+	reported   map[*parser.GateCall]bool
+	conditions [][]string // Classical names referenced by enclosing if conditions
+	corrected  []feedForward
+	constants  map[string]int64
+	loops      map[parser.Statement]loopSummary // Last analyzed iteration of each loop
+}
+
+// loopSummary records the state at the start and end of a loop iteration
+type loopSummary struct {
+	entry measurementState
+	exit  measurementState
+}
+
+// feedForward records a gate conditioned on a qubit's own measurement result
+type feedForward struct {
+	name    string
+	indices []int
+}
+
+// CheckAST performs flow-sensitive analysis of measurements and gate uses
+func (r *QubitUsedAfterMeasurementRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	analysis := &measurementAnalysis{
+		ctx:       ctx,
+		reported:  make(map[*parser.GateCall]bool),
+		constants: astutil.IntegerConstants(program),
+		loops:     make(map[parser.Statement]loopSummary),
+	}
+
+	r.analyzeStatements(program.Statements, make(measurementState), analysis)
+
+	return analysis.violations
+}
+
+// analyzeStatements walks a statement sequence, updating the measurement state in place
+func (r *QubitUsedAfterMeasurementRule) analyzeStatements(statements []parser.Statement, state measurementState, analysis *measurementAnalysis) {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.Measurement:
+			r.markMeasured(s, state, analysis)
+
+		case *parser.Reset:
+			r.clearOperand(s.Qubit, state, analysis)
+
+		case *parser.GateCall:
+			r.checkGateCall(s, state, analysis)
+
+		case *parser.IfStatement:
+			analysis.conditions = append(analysis.conditions, r.referencedNames(s.Condition))
+			corrections := len(analysis.corrected)
+
+			thenState := state.clone()
+			r.analyzeStatements(s.ThenBody, thenState, analysis)
+			elseState := state.clone()
+			r.analyzeStatements(s.ElseBody, elseState, analysis)
+
+			analysis.conditions = analysis.conditions[:len(analysis.conditions)-1]
+
+			// A qubit may have been measured if it was measured on either branch
+			state.replace(thenState.merge(elseState))
+
+			// A conditional correction on the measured value (active reset) leaves the
+			// qubit in a known state on both paths
+			for _, correction := range analysis.corrected[corrections:] {
+				if reg, exists := state[correction.name]; exists {
+					for _, index := range r.clearIndices(correction.indices) {
+						reg.clear(index)
+					}
+				}
+			}
+			if len(analysis.conditions) == 0 {
+				analysis.corrected = nil
+			}
+
+		case *parser.ForStatement:
+			r.analyzeLoop(s, s.Body, r.iteratesOnce(s.Iterable, analysis), state, analysis)
+
+		case *parser.WhileStatement:
+			r.analyzeLoop(s, s.Body, false, state, analysis)
+
+		case *parser.BoxStatement:
+			r.analyzeStatements(s.Body, state, analysis)
+		}
+	}
+}
+
+// analyzeLoop analyzes a loop body until the state at the start of an
+// iteration stops changing, so that measurements at the end of an iteration are
+// seen by gates at the start of the next one. An iteration starting from the
+// same state as the last analyzed one is not walked again, which keeps nested
+// loops from being re-analyzed for every iteration of the enclosing loop.
+// Unless the loop is known to run at least once, it may also execute zero times.
+func (r *QubitUsedAfterMeasurementRule) analyzeLoop(loop parser.Statement, body []parser.Statement, runs bool, state measurementState, analysis *measurementAnalysis) {
+	entry := state.clone()
+	var exit measurementState
+	for {
+		if last, ok := analysis.loops[loop]; ok && last.entry.equal(entry) {
+			exit = last.exit.clone()
+		} else {
+			exit = entry.clone()
+			r.analyzeStatements(body, exit, analysis)
+			analysis.loops[loop] = loopSummary{entry: entry.clone(), exit: exit.clone()}
+		}
+
+		next := entry.merge(exit)
+		if next.equal(entry) {
+			break
+		}
+		entry = next
+	}
+
+	if !runs {
+		exit = state.merge(exit)
+	}
+	state.replace(exit)
+}
+
+// iteratesOnce reports whether a for loop iterable has at least one element
+func (r *QubitUsedAfterMeasurementRule) iteratesOnce(iterable parser.Expression, analysis *measurementAnalysis) bool {
+	switch e := iterable.(type) {
+	case *parser.SetExpression:
+		return len(e.Elements) > 0
+	case *parser.RangeExpression:
+		start, ok := astutil.EvaluateInteger(e.Start, analysis.constants)
+		if !ok {
+			return false
+		}
+		end, ok := astutil.EvaluateInteger(e.EndIndex, analysis.constants)
+		if !ok {
+			return false
+		}
+		if e.Step == nil {
+			return start <= end
+		}
+		step, ok := astutil.EvaluateInteger(e.Step, analysis.constants)
+		return ok && step > 0 && start <= end
+	}
+	return false
+}
+
+// checkGateCall reports gate operands that refer to measured qubits
+func (r *QubitUsedAfterMeasurementRule) checkGateCall(gateCall *parser.GateCall, state measurementState, analysis *measurementAnalysis) {
+	for _, operand := range gateCall.Qubits {
+		name, indices, ok := r.resolveOperand(operand, analysis.constants)
+		if !ok {
+			continue
+		}
+
+		reg, exists := state[name]
+		if !exists {
+			continue
+		}
+
+		target, measured := reg.measuredTarget(indices)
+		if !measured {
+			continue
+		}
+
+		// Feed-forward on the measurement result (e.g. active reset) is intentional
+		if r.conditionedOn(target, analysis) {
+			for _, index := range r.clearIndices(indices) {
+				reg.clear(index)
+			}
+			analysis.corrected = append(analysis.corrected, feedForward{name: name, indices: indices})
+			continue
+		}
+
+		if analysis.reported[gateCall] {
+			continue
+		}
+		analysis.reported[gateCall] = true
+
+		qubitName := r.operandText(name, indices)
+		violation := r.NewViolationBuilder().
+			WithMessage(fmt.Sprintf("Qubit '%s' is used by gate '%s' after measurement without an intervening reset.", qubitName, gateCall.Name)).
+			WithFile(analysis.ctx.File).
+			WithNode(gateCall).
+			WithNodeName(qubitName).
+			AsWarning().
+			Build()
+		analysis.violations = append(analysis.violations, violation)
+	}
+}
+
+// markMeasured records a measurement in the state. A measurement whose indices
+// are not constant may measure any qubit of the register, so it conservatively
+// marks the whole register.
+func (r *QubitUsedAfterMeasurementRule) markMeasured(measurement *parser.Measurement, state measurementState, analysis *measurementAnalysis) {
+	name, indices, ok := r.resolveOperand(measurement.Qubit, analysis.constants)
+	if !ok {
+		name, indices = r.baseName(measurement.Qubit), nil
+		if name == "" {
+			return
+		}
+	}
+
+	target := ""
+	if measurement.Target != nil {
+		target = r.baseName(measurement.Target)
+	}
+
+	reg, exists := state[name]
+	if !exists || indices == nil {
+		reg = &measuredRegister{
+			except:  make(map[int]bool),
+			indices: make(map[int]string),
+		}
+		state[name] = reg
+	}
+
+	if indices == nil {
+		reg.all = true
+		reg.target = target
+		return
+	}
+
+	for _, index := range indices {
+		delete(reg.except, index)
+		reg.indices[index] = target
+	}
+}
+
+// clearOperand removes the measured state of a reset operand. A reset whose
+// indices are not constant, as in a loop over the register, may reset any of
+// its qubits, so it conservatively clears the whole register.
+func (r *QubitUsedAfterMeasurementRule) clearOperand(operand parser.Expression, state measurementState, analysis *measurementAnalysis) {
+	name, indices, ok := r.resolveOperand(operand, analysis.constants)
+	if !ok {
+		name, indices = r.baseName(operand), nil
+	}
+
+	if indices == nil {
+		delete(state, name)
+		return
+	}
+
+	if reg, exists := state[name]; exists {
+		for _, index := range indices {
+			reg.clear(index)
+		}
+	}
+}
+
+// clearIndices returns the indices to clear for a feed-forward correction
+func (r *QubitUsedAfterMeasurementRule) clearIndices(indices []int) []int {
+	if indices == nil {
+		return []int{-1}
+	}
+	return indices
+}
+
+// conditionedOn reports whether an enclosing if condition reads the given classical register
+func (r *QubitUsedAfterMeasurementRule) conditionedOn(target string, analysis *measurementAnalysis) bool {
+	if target == "" {
+		return false
+	}
+	for _, names := range analysis.conditions {
+		for _, name := range names {
+			if name == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// referencedNames collects the identifiers used in a condition expression
+func (r *QubitUsedAfterMeasurementRule) referencedNames(expr parser.Expression) []string {
+	var names []string
+	astutil.VisitAllNodes(expr, func(node parser.Node) {
+		switch n := node.(type) {
+		case *parser.Identifier:
+			names = append(names, n.Name)
+		case *parser.IndexedIdentifier:
+			names = append(names, n.Name)
+		case *parser.RangedIdentifier:
+			names = append(names, n.Name)
+		}
+	})
+	return names
+}
+
+// resolveOperand returns the register name and the constant indices addressed by
+// an operand, folding integer constants. A nil index slice means the whole
+// register; ok is false when the indices cannot be determined statically.
+func (r *QubitUsedAfterMeasurementRule) resolveOperand(expr parser.Expression, constants map[string]int64) (string, []int, bool) {
+	switch e := expr.(type) {
+	case *parser.Identifier:
+		return e.Name, nil, true
+
+	case *parser.IndexedIdentifier:
+		index, ok := astutil.EvaluateInteger(e.Index, constants)
+		if !ok || index < 0 {
+			return "", nil, false
+		}
+		return e.Name, []int{int(index)}, true
+
+	case *parser.RangedIdentifier:
+		if e.Step != nil {
+			return "", nil, false
+		}
+		start, ok := astutil.EvaluateInteger(e.Start, constants)
+		if !ok || start < 0 {
+			return "", nil, false
+		}
+		end, ok := astutil.EvaluateInteger(e.EndIndex, constants)
+		if !ok || end < start || end-start >= maxResolvedRegisterSize {
+			return "", nil, false
+		}
+		indices := make([]int, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			indices = append(indices, int(i))
+		}
+		return e.Name, indices, true
+	}
+
+	return "", nil, false
+}
+
+// baseName returns the register name of a classical target expression
+func (r *QubitUsedAfterMeasurementRule) baseName(expr parser.Expression) string {
+	switch e := expr.(type) {
+	case *parser.Identifier:
+		return e.Name
+	case *parser.IndexedIdentifier:
+		return e.Name
+	case *parser.RangedIdentifier:
+		return e.Name
+	}
+	return ""
+}
+
+// operandText formats an operand for messages
+func (r *QubitUsedAfterMeasurementRule) operandText(name string, indices []int) string {
+	if len(indices) == 1 {
+		return fmt.Sprintf("%s[%d]", name, indices[0])
+	}
+	return name
+}
+
+// measuredTarget reports whether any of the addressed qubits has been measured,
+// along with the classical register that received the result
+func (m *measuredRegister) measuredTarget(indices []int) (string, bool) {
+	if indices == nil {
+		if m.all {
+			return m.target, true
+		}
+		for _, target := range m.indices {
+			return target, true
+		}
+		return "", false
+	}
+
+	for _, index := range indices {
+		if target, exists := m.indices[index]; exists {
+			return target, true
+		}
+		if m.all && !m.except[index] {
+			return m.target, true
+		}
+	}
+	return "", false
+}
+
+// clear marks a single element as reset; -1 resets the whole register
+func (m *measuredRegister) clear(index int) {
+	if index < 0 {
+		m.all = false
+		m.indices = make(map[int]string)
+		return
+	}
+	delete(m.indices, index)
+	if m.all {
+		m.except[index] = true
+	}
+}
+
+// replace overwrites the state in place with another one
+func (s measurementState) replace(other measurementState) {
+	for name := range s {
+		delete(s, name)
+	}
+	for name, reg := range other {
+		s[name] = reg
+	}
+}
+
+// equal reports whether two states consider the same qubits measured
+func (s measurementState) equal(other measurementState) bool {
+	measured := func(state measurementState) int {
+		count := 0
+		for _, reg := range state {
+			if reg.all || len(reg.indices) > 0 {
+				count++
+			}
+		}
+		return count
+	}
+	if measured(s) != measured(other) {
+		return false
+	}
+
+	for name, reg := range s {
+		if !reg.all && len(reg.indices) == 0 {
+			continue
+		}
+		existing, exists := other[name]
+		if !exists || !reg.equal(existing) {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns a deep copy of the measurement state
+func (s measurementState) clone() measurementState {
+	copied := make(measurementState, len(s))
+	for name, reg := range s {
+		copied[name] = reg.clone()
+	}
+	return copied
+}
+
+// merge returns the union of two states: a qubit is considered measured if it
+// is measured in either state
+func (s measurementState) merge(other measurementState) measurementState {
+	merged := s.clone()
+	for name, reg := range other {
+		existing, exists := merged[name]
+		if !exists {
+			merged[name] = reg.clone()
+			continue
+		}
+
+		if reg.all {
+			if !existing.all {
+				existing.all = true
+				existing.target = reg.target
+				existing.except = make(map[int]bool)
+				for index := range reg.except {
+					existing.except[index] = true
+				}
+			} else {
+				// Only elements reset on both paths stay reset
+				for index := range existing.except {
+					if !reg.except[index] {
+						delete(existing.except, index)
+					}
+				}
+			}
+		} else if existing.all {
+			// Elements reset on one path but measured on the other remain measured
+			for index := range existing.except {
+				if _, measured := reg.indices[index]; measured {
+					delete(existing.except, index)
+				}
+			}
+		}
+
+		for index, target := range reg.indices {
+			if _, exists := existing.indices[index]; !exists {
+				existing.indices[index] = target
+			}
+		}
+	}
+
+	// Drop registers with nothing left measured
+	for name, reg := range merged {
+		if !reg.all && len(reg.indices) == 0 {
+			delete(merged, name)
+		}
+	}
+	return merged
+}
+
+// equal reports whether two register states have the same qubits measured into
+// the same targets
+func (m *measuredRegister) equal(other *measuredRegister) bool {
+	if m.all != other.all || len(m.indices) != len(other.indices) {
+		return false
+	}
+	if m.all && (m.target != other.target || !maps.Equal(m.except, other.except)) {
+		return false
+	}
+	return maps.Equal(m.indices, other.indices)
+}
+
+// clone returns a deep copy of the register state
+func (m *measuredRegister) clone() *measuredRegister {
+	copied := &measuredRegister{
+		all:     m.all,
+		target:  m.target,
+		except:  make(map[int]bool, len(m.except)),
+		indices: make(map[int]string, len(m.indices)),
+	}
+	for index := range m.except {
+		copied.except[index] = true
+	}
+	for index, target := range m.indices {
+		copied.indices[index] = target
+	}
+	return copied
+}
@@ -0,0 +1,288 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// DuplicateQubitOperandRule implements QAS014 using AST-based analysis.
+// Each operand of a gate call is resolved to the physical qubits it refers to,
+// following register broadcasts, let aliases and constant-folded indices.
+type DuplicateQubitOperandRule struct {
+	*ASTRuleBase
+}
+
+// NewDuplicateQubitOperandRule creates a new AST-based duplicate qubit operand rule
+func NewDuplicateQubitOperandRule() ASTRule {
+	return &DuplicateQubitOperandRule{
+		ASTRuleBase: NewASTRuleBase("QAS014"),
+	}
+}
+
+// qubitRef identifies a single qubit; index -1 stands for a qubit of unknown position
+// (e.g. a gate parameter or a register of unknown size)
+type qubitRef struct {
+	register string
+	index    int
+}
+
+// String formats the qubit reference for messages
+func (q qubitRef) String() string {
+	if q.index < 0 {
+		return q.register
+	}
+	return fmt.Sprintf("%s[%d]", q.register, q.index)
+}
+
+// maxResolvedRegisterSize bounds the registers expanded to individual qubits;
+// larger registers are treated like registers of unknown size
+const maxResolvedRegisterSize = 1 << 16
+
+// operandResolver resolves operand expressions to qubit references
+type operandResolver struct {
+	sizes     map[string]int
+	aliases   map[string][]qubitRef
+	constants map[string]int64
+}
+
+// CheckAST checks every gate call for operands that refer to the same qubit
+func (r *DuplicateQubitOperandRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	resolver := &operandResolver{
+		sizes:     make(map[string]int),
+		aliases:   make(map[string][]qubitRef),
+		constants: astutil.IntegerConstants(program),
+	}
+
+	var violations []*Violation
+	r.checkStatements(program.Statements, resolver, ctx, &violations)
+	return violations
+}
+
+// checkStatements walks statements in program order so that aliases are resolved
+// with the registers visible at their declaration
+func (r *DuplicateQubitOperandRule) checkStatements(statements []parser.Statement, resolver *operandResolver, ctx *CheckContext, violations *[]*Violation) {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.QuantumDeclaration:
+			// Single qubits and registers of unknown or very large size are
+			// treated as opaque qubits
+			size := 0
+			if s.Size != nil {
+				if value, ok := astutil.EvaluateInteger(s.Size, resolver.constants); ok && value <= maxResolvedRegisterSize {
+					size = int(value)
+				}
+			}
+			resolver.sizes[s.Identifier] = size
+			delete(resolver.aliases, s.Identifier)
+
+		case *parser.AliasDeclaration:
+			if refs, ok := resolver.resolve(s.Value); ok {
+				resolver.aliases[s.Identifier] = refs
+			} else {
+				delete(resolver.aliases, s.Identifier)
+			}
+
+		case *parser.GateCall:
+			if violation := r.checkGateCall(s, resolver, ctx); violation != nil {
+				*violations = append(*violations, violation)
+			}
+
+		case *parser.GateDefinition:
+			// Gate qubit parameters are distinct single qubits
+			local := &operandResolver{
+				sizes:     make(map[string]int),
+				aliases:   make(map[string][]qubitRef),
+				constants: resolver.constants,
+			}
+			for _, qubit := range s.Qubits {
+				local.sizes[qubit.Name] = 0
+			}
+			r.checkStatements(s.Body, local, ctx, violations)
+
+		case *parser.IfStatement:
+			r.checkStatements(s.ThenBody, resolver, ctx, violations)
+			r.checkStatements(s.ElseBody, resolver, ctx, violations)
+
+		case *parser.ForStatement:
+			r.checkStatements(s.Body, resolver, ctx, violations)
+
+		case *parser.WhileStatement:
+			r.checkStatements(s.Body, resolver, ctx, violations)
+
+		case *parser.BoxStatement:
+			r.checkStatements(s.Body, resolver, ctx, violations)
+		}
+	}
+}
+
+// checkGateCall reports the first operand that overlaps with an earlier one.
+// Register operands are broadcast: the k-th call instance uses the k-th qubit of
+// every register operand together with all single-qubit operands.
+func (r *DuplicateQubitOperandRule) checkGateCall(gateCall *parser.GateCall, resolver *operandResolver, ctx *CheckContext) *Violation {
+	operands := make([][]qubitRef, len(gateCall.Qubits))
+	instances := 1
+	for i, operand := range gateCall.Qubits {
+		refs, ok := resolver.resolve(operand)
+		if !ok || len(refs) == 0 {
+			// Operands that cannot be resolved statically are never considered duplicates
+			continue
+		}
+		operands[i] = refs
+		if len(refs) > instances {
+			instances = len(refs)
+		}
+	}
+
+	for k := 0; k < instances; k++ {
+		seen := make(map[qubitRef]bool)
+		for i, refs := range operands {
+			if len(refs) == 0 {
+				continue
+			}
+
+			ref := refs[0]
+			if len(refs) > 1 {
+				if k >= len(refs) {
+					// Mismatched broadcast sizes are reported by QAS006
+					continue
+				}
+				ref = refs[k]
+			}
+
+			if seen[ref] {
+				return r.NewViolationBuilder().
+					WithMessage(fmt.Sprintf("Qubit '%s' is used more than once in gate call '%s'.", ref, gateCall.Name)).
+					WithFile(ctx.File).
+					WithNode(gateCall.Qubits[i]).
+					WithNodeName(ref.String()).
+					AsError().
+					Build()
+			}
+			seen[ref] = true
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the qubits referred to by an operand expression, in order
+func (o *operandResolver) resolve(expr parser.Expression) ([]qubitRef, bool) {
+	switch e := expr.(type) {
+	case *parser.Identifier:
+		return o.resolveName(e.Name), true
+
+	case *parser.IndexedIdentifier:
+		refs := o.resolveName(e.Name)
+		index, ok := astutil.EvaluateInteger(e.Index, o.constants)
+		if !ok {
+			return nil, false
+		}
+		if ref, ok := o.element(refs, e.Name, int(index)); ok {
+			return []qubitRef{ref}, true
+		}
+		return nil, false
+
+	case *parser.RangedIdentifier:
+		return o.resolveRange(e)
+
+	case *parser.BinaryExpression:
+		if e.Operator != "++" {
+			return nil, false
+		}
+		left, ok := o.resolve(e.Left)
+		if !ok {
+			return nil, false
+		}
+		right, ok := o.resolve(e.Right)
+		if !ok {
+			return nil, false
+		}
+		return append(append([]qubitRef{}, left...), right...), true
+	}
+
+	return nil, false
+}
+
+// resolveName expands a register or alias name to its qubits
+func (o *operandResolver) resolveName(name string) []qubitRef {
+	if refs, exists := o.aliases[name]; exists {
+		return refs
+	}
+
+	size, exists := o.sizes[name]
+	if !exists || size <= 0 {
+		return []qubitRef{{register: name, index: -1}}
+	}
+
+	refs := make([]qubitRef, size)
+	for i := range refs {
+		refs[i] = qubitRef{register: name, index: i}
+	}
+	return refs
+}
+
+// element selects the qubit at index, supporting negative indices from the end
+func (o *operandResolver) element(refs []qubitRef, name string, index int) (qubitRef, bool) {
+	if len(refs) == 1 && refs[0].index < 0 {
+		// Register of unknown size: indices still identify distinct qubits
+		return qubitRef{register: name, index: index}, index >= 0
+	}
+	if index < 0 {
+		index += len(refs)
+	}
+	if index < 0 || index >= len(refs) {
+		// Out-of-bounds access is reported by QAS004
+		return qubitRef{}, false
+	}
+	return refs[index], true
+}
+
+// resolveRange expands a ranged operand such as q[0:2] or q[::2]
+func (o *operandResolver) resolveRange(e *parser.RangedIdentifier) ([]qubitRef, bool) {
+	refs := o.resolveName(e.Name)
+	if len(refs) == 1 && refs[0].index < 0 {
+		return nil, false
+	}
+
+	bound := func(expr parser.Expression, fallback int) (int, bool) {
+		if expr == nil {
+			return fallback, true
+		}
+		value, ok := astutil.EvaluateInteger(expr, o.constants)
+		if !ok {
+			return 0, false
+		}
+		if value < 0 {
+			value += int64(len(refs))
+		}
+		return int(value), true
+	}
+
+	start, ok := bound(e.Start, 0)
+	if !ok {
+		return nil, false
+	}
+	end, ok := bound(e.EndIndex, len(refs)-1)
+	if !ok {
+		return nil, false
+	}
+	step := 1
+	if e.Step != nil {
+		value, ok := astutil.EvaluateInteger(e.Step, o.constants)
+		if !ok || value == 0 {
+			return nil, false
+		}
+		step = int(value)
+	}
+
+	var selected []qubitRef
+	for i := start; (step > 0 && i <= end) || (step < 0 && i >= end); i += step {
+		if i < 0 || i >= len(refs) {
+			return nil, false
+		}
+		selected = append(selected, refs[i])
+	}
+	return selected, true
+}
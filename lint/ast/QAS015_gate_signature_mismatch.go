@@ -0,0 +1,105 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// GateSignatureMismatchRule implements QAS015 using AST-based analysis.
+// It checks the parameter and qubit counts of every gate call against the
+// gate definition or the built-in signature.
+type GateSignatureMismatchRule struct {
+	*ASTRuleBase
+}
+
+// NewGateSignatureMismatchRule creates a new AST-based gate signature mismatch rule
+func NewGateSignatureMismatchRule() ASTRule {
+	return &GateSignatureMismatchRule{
+		ASTRuleBase: NewASTRuleBase("QAS015"),
+	}
+}
+
+// CheckAST checks every gate call against its resolved signature
+func (r *GateSignatureMismatchRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	definitions := astutil.GateDefinitionsByName(program)
+	constants := astutil.IntegerConstants(program)
+
+	for _, gateCall := range astutil.FindNodesByType(program, (*parser.GateCall)(nil)) {
+		signature, ok := astutil.ResolveGateSignature(gateCall.Name, definitions)
+		if !ok {
+			// Unknown gates are reported by QAS002
+			continue
+		}
+
+		if len(gateCall.Parameters) != signature.Parameters {
+			violation := r.NewViolationBuilder().
+				WithMessage(fmt.Sprintf("Gate '%s' expects %s, got %d.",
+					gateCall.Name, pluralize(signature.Parameters, "parameter"), len(gateCall.Parameters))).
+				WithFile(ctx.File).
+				WithNode(gateCall).
+				WithNodeName(gateCall.Name).
+				AsError().
+				Build()
+			violations = append(violations, violation)
+		}
+
+		controls, ok := r.countControls(gateCall, constants)
+		if !ok {
+			// Control counts that are not compile-time constants cannot be checked
+			continue
+		}
+
+		expected := signature.Qubits + controls
+		if len(gateCall.Qubits) != expected {
+			subject := fmt.Sprintf("Gate '%s'", gateCall.Name)
+			if controls > 0 {
+				subject = fmt.Sprintf("%s with %s", subject, pluralize(controls, "control"))
+			}
+			violation := r.NewViolationBuilder().
+				WithMessage(fmt.Sprintf("%s expects %s, got %d.",
+					subject, pluralize(expected, "qubit"), len(gateCall.Qubits))).
+				WithFile(ctx.File).
+				WithNode(gateCall).
+				WithNodeName(gateCall.Name).
+				AsError().
+				Build()
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// countControls returns the number of control qubits added by ctrl/negctrl modifiers
+func (r *GateSignatureMismatchRule) countControls(gateCall *parser.GateCall, constants map[string]int64) (int, bool) {
+	controls := 0
+	for _, modifier := range gateCall.Modifiers {
+		if modifier.Type != "ctrl" && modifier.Type != "negctrl" {
+			continue
+		}
+
+		if len(modifier.Parameters) == 0 {
+			controls++
+			continue
+		}
+
+		count, ok := astutil.EvaluateInteger(modifier.Parameters[0], constants)
+		if !ok || count < 1 {
+			return 0, false
+		}
+		controls += int(count)
+	}
+	return controls, true
+}
+
+// pluralize formats a count with a singular or plural noun
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
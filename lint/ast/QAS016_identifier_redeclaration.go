@@ -0,0 +1,161 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// IdentifierRedeclarationRule implements QAS016 using AST-based analysis.
+// Declarations are tracked per scope: a second declaration in the same scope is an
+// error, and a declaration that hides one from an enclosing scope is a warning.
+type IdentifierRedeclarationRule struct {
+	*ASTRuleBase
+}
+
+// NewIdentifierRedeclarationRule creates a new AST-based identifier redeclaration rule
+func NewIdentifierRedeclarationRule() ASTRule {
+	return &IdentifierRedeclarationRule{
+		ASTRuleBase: NewASTRuleBase("QAS016"),
+	}
+}
+
+// declarationScope holds the identifiers declared in one block
+type declarationScope struct {
+	parent       *declarationScope
+	declarations map[string]parser.Node
+	// gate marks the scope of a gate or subroutine body, which only sees global
+	// constants, gates and subroutines
+	gate bool
+}
+
+// newDeclarationScope creates a scope nested in parent
+func newDeclarationScope(parent *declarationScope, gate bool) *declarationScope {
+	return &declarationScope{
+		parent:       parent,
+		declarations: make(map[string]parser.Node),
+		gate:         gate,
+	}
+}
+
+// lookupEnclosing finds a declaration of name visible from an enclosing scope
+func (s *declarationScope) lookupEnclosing(name string) (parser.Node, bool) {
+	crossedGate := s.gate
+	for scope := s.parent; scope != nil; scope = scope.parent {
+		if node, exists := scope.declarations[name]; exists {
+			if !crossedGate || visibleInGate(node) {
+				return node, true
+			}
+		}
+		crossedGate = crossedGate || scope.gate
+	}
+	return nil, false
+}
+
+// visibleInGate reports whether a global declaration can be referenced from a gate or subroutine body
+func visibleInGate(node parser.Node) bool {
+	switch n := node.(type) {
+	case *parser.GateDefinition, *parser.SubroutineDefinition:
+		return true
+	case *parser.ClassicalDeclaration:
+		return n.Const
+	}
+	return false
+}
+
+// CheckAST checks all scopes of the program for redeclared and shadowed identifiers
+func (r *IdentifierRedeclarationRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+	r.checkStatements(program.Statements, newDeclarationScope(nil, false), ctx, &violations)
+	return violations
+}
+
+// checkStatements declares identifiers in order and descends into nested scopes
+func (r *IdentifierRedeclarationRule) checkStatements(statements []parser.Statement, scope *declarationScope, ctx *CheckContext, violations *[]*Violation) {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.QuantumDeclaration:
+			r.declare(s.Identifier, s, scope, ctx, violations)
+
+		case *parser.ClassicalDeclaration:
+			r.declare(s.Identifier, s, scope, ctx, violations)
+
+		case *parser.AliasDeclaration:
+			r.declare(s.Identifier, s, scope, ctx, violations)
+
+		case *parser.GateDefinition:
+			r.declare(s.Name, s, scope, ctx, violations)
+
+			gateScope := newDeclarationScope(scope, true)
+			for i := range s.Parameters {
+				r.declare(s.Parameters[i].Name, &s.Parameters[i], gateScope, ctx, violations)
+			}
+			for i := range s.Qubits {
+				r.declare(s.Qubits[i].Name, &s.Qubits[i], gateScope, ctx, violations)
+			}
+			r.checkStatements(s.Body, gateScope, ctx, violations)
+
+		case *parser.SubroutineDefinition:
+			r.declare(s.Name, s, scope, ctx, violations)
+
+			// Subroutine bodies see the same global identifiers as gate bodies
+			subroutineScope := newDeclarationScope(scope, true)
+			for i := range s.Parameters {
+				r.declare(s.Parameters[i].Name, &s.Parameters[i], subroutineScope, ctx, violations)
+			}
+			r.checkStatements(s.Body, subroutineScope, ctx, violations)
+
+		case *parser.IfStatement:
+			r.checkStatements(s.ThenBody, newDeclarationScope(scope, false), ctx, violations)
+			r.checkStatements(s.ElseBody, newDeclarationScope(scope, false), ctx, violations)
+
+		case *parser.ForStatement:
+			// The loop variable belongs to the loop body scope
+			loopScope := newDeclarationScope(scope, false)
+			if s.Variable != "" {
+				r.declare(s.Variable, s, loopScope, ctx, violations)
+			}
+			r.checkStatements(s.Body, loopScope, ctx, violations)
+
+		case *parser.WhileStatement:
+			r.checkStatements(s.Body, newDeclarationScope(scope, false), ctx, violations)
+
+		case *parser.BoxStatement:
+			r.checkStatements(s.Body, newDeclarationScope(scope, false), ctx, violations)
+		}
+	}
+}
+
+// declare records a declaration and reports conflicts with earlier declarations
+func (r *IdentifierRedeclarationRule) declare(name string, node parser.Node, scope *declarationScope, ctx *CheckContext, violations *[]*Violation) {
+	if name == "" {
+		return
+	}
+
+	if previous, exists := scope.declarations[name]; exists {
+		violation := r.NewViolationBuilder().
+			WithMessage(fmt.Sprintf("Identifier '%s' is already declared in this scope.", name)).
+			WithFile(ctx.File).
+			WithNode(node).
+			WithNodeName(name).
+			WithRelated(previous, fmt.Sprintf("'%s' was first declared here", name)).
+			AsError().
+			Build()
+		*violations = append(*violations, violation)
+		return
+	}
+
+	if previous, exists := scope.lookupEnclosing(name); exists {
+		violation := r.NewViolationBuilder().
+			WithMessage(fmt.Sprintf("Identifier '%s' shadows a declaration in an enclosing scope.", name)).
+			WithFile(ctx.File).
+			WithNode(node).
+			WithNodeName(name).
+			WithRelated(previous, fmt.Sprintf("'%s' is declared here", name)).
+			AsWarning().
+			Build()
+		*violations = append(*violations, violation)
+	}
+
+	scope.declarations[name] = node
+}
@@ -0,0 +1,179 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// RecursiveGateDefinitionRule implements QAS017 using AST-based analysis.
+// It builds the call graph between gate definitions and reports every gate that
+// lies on a cycle, so the number of violations is bounded by the number of gates.
+type RecursiveGateDefinitionRule struct {
+	*ASTRuleBase
+}
+
+// NewRecursiveGateDefinitionRule creates a new AST-based recursive gate definition rule
+func NewRecursiveGateDefinitionRule() ASTRule {
+	return &RecursiveGateDefinitionRule{
+		ASTRuleBase: NewASTRuleBase("QAS017"),
+	}
+}
+
+// gateCallGraph maps each defined gate to the first call of every gate it invokes
+type gateCallGraph struct {
+	order   []string
+	defs    map[string]*parser.GateDefinition
+	callees map[string][]string
+	sites   map[string]map[string]*parser.GateCall
+}
+
+// CheckAST reports each recursive gate once, with the shortest cycle through it
+func (r *RecursiveGateDefinitionRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	graph := buildGateCallGraph(program)
+	component := graph.components()
+
+	var violations []*Violation
+	for _, name := range graph.order {
+		cycle := graph.cycleThrough(name, component)
+		if cycle == nil {
+			continue
+		}
+		root := graph.defs[name]
+		path := strings.Join(append(append([]string{}, cycle...), name), " -> ")
+
+		builder := r.NewViolationBuilder().
+			WithMessage(fmt.Sprintf("Gate '%s' is defined recursively: %s.", root.Name, path)).
+			WithFile(ctx.File).
+			WithNode(root).
+			WithNodeName(root.Name)
+		for i, caller := range cycle {
+			callee := cycle[(i+1)%len(cycle)]
+			builder.WithRelated(graph.sites[caller][callee], fmt.Sprintf("'%s' calls '%s' here", caller, callee))
+		}
+		violations = append(violations, builder.AsError().Build())
+	}
+
+	return violations
+}
+
+// buildGateCallGraph collects calls between user-defined gates
+func buildGateCallGraph(program *parser.Program) *gateCallGraph {
+	graph := &gateCallGraph{
+		defs:    make(map[string]*parser.GateDefinition),
+		callees: make(map[string][]string),
+		sites:   make(map[string]map[string]*parser.GateCall),
+	}
+
+	definitions := astutil.FindNodesByType(program, (*parser.GateDefinition)(nil))
+	for _, gateDef := range definitions {
+		if _, exists := graph.defs[gateDef.Name]; exists {
+			// Duplicate definitions are reported by QAS016
+			continue
+		}
+		graph.order = append(graph.order, gateDef.Name)
+		graph.defs[gateDef.Name] = gateDef
+		graph.sites[gateDef.Name] = make(map[string]*parser.GateCall)
+	}
+
+	for _, name := range graph.order {
+		for _, stmt := range graph.defs[name].Body {
+			astutil.VisitAllNodes(stmt, func(node parser.Node) {
+				call, ok := node.(*parser.GateCall)
+				if !ok {
+					return
+				}
+				if _, defined := graph.defs[call.Name]; !defined {
+					return
+				}
+				if _, seen := graph.sites[name][call.Name]; !seen {
+					graph.sites[name][call.Name] = call
+					graph.callees[name] = append(graph.callees[name], call.Name)
+				}
+			})
+		}
+	}
+
+	return graph
+}
+
+// components assigns each gate the strongly connected component it belongs to,
+// using Tarjan's algorithm. Gates share a component exactly when they call each
+// other, directly or through other gates.
+func (g *gateCallGraph) components() map[string]int {
+	component := make(map[string]int)
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	next, count := 0, 0
+
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = next
+		lowlink[name] = next
+		next++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, callee := range g.callees[name] {
+			if _, visited := index[callee]; !visited {
+				connect(callee)
+				lowlink[name] = min(lowlink[name], lowlink[callee])
+			} else if onStack[callee] {
+				lowlink[name] = min(lowlink[name], index[callee])
+			}
+		}
+
+		if lowlink[name] == index[name] {
+			for {
+				member := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[member] = false
+				component[member] = count
+				if member == name {
+					break
+				}
+			}
+			count++
+		}
+	}
+
+	for _, name := range g.order {
+		if _, visited := index[name]; !visited {
+			connect(name)
+		}
+	}
+
+	return component
+}
+
+// cycleThrough returns the shortest cycle from start back to itself, or nil when
+// start is not recursive. Only gates of the same component can lie on the cycle.
+func (g *gateCallGraph) cycleThrough(start string, component map[string]int) []string {
+	parent := map[string]string{start: ""}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, callee := range g.callees[current] {
+			if callee == start {
+				var cycle []string
+				for name := current; name != ""; name = parent[name] {
+					cycle = append([]string{name}, cycle...)
+				}
+				return cycle
+			}
+			if _, seen := parent[callee]; seen || component[callee] != component[start] {
+				continue
+			}
+			parent[callee] = current
+			queue = append(queue, callee)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// ClassicalReadBeforeAssignmentRule implements QAS018 using AST-based analysis.
+// It tracks which classical variables may still be uninitialized through the
+// statement sequence and reports reads of them.
+type ClassicalReadBeforeAssignmentRule struct {
+	*ASTRuleBase
+}
+
+// NewClassicalReadBeforeAssignmentRule creates a new AST-based classical read before assignment rule
+func NewClassicalReadBeforeAssignmentRule() ASTRule {
+	return &ClassicalReadBeforeAssignmentRule{
+		ASTRuleBase: NewASTRuleBase("QAS018"),
+	}
+}
+
+// initState maps tracked variable names to whether they may still be uninitialized
+type initState map[string]bool
+
+// clone returns an independent copy of the state
+func (s initState) clone() initState {
+	copied := make(initState, len(s))
+	for name, uninitialized := range s {
+		copied[name] = uninitialized
+	}
+	return copied
+}
+
+// merge returns the state after two alternative paths: a variable may be
+// uninitialized if it may be uninitialized on either path
+func (s initState) merge(other initState) initState {
+	merged := s.clone()
+	for name, uninitialized := range other {
+		merged[name] = merged[name] || uninitialized
+	}
+	return merged
+}
+
+// readAnalysis holds the state shared while walking the program
+type readAnalysis struct {
+	ctx        *CheckContext
+	violations []*Violation
+	reported   map[parser.Node]map[string]bool
+}
+
+// CheckAST performs flow-sensitive analysis of classical reads and assignments
+func (r *ClassicalReadBeforeAssignmentRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	analysis := &readAnalysis{
+		ctx:      ctx,
+		reported: make(map[parser.Node]map[string]bool),
+	}
+
+	r.analyzeStatements(program.Statements, make(initState), analysis)
+
+	return analysis.violations
+}
+
+// analyzeStatements walks a statement sequence, updating the state in place.
+// It returns the names declared directly in the sequence.
+func (r *ClassicalReadBeforeAssignmentRule) analyzeStatements(statements []parser.Statement, state initState, analysis *readAnalysis) []string {
+	var declared []string
+
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.ClassicalDeclaration:
+			r.checkReads(stmt, state, analysis, s.Size, s.Initializer)
+			// Stretch durations are resolved by the compiler and never assigned
+			state[s.Identifier] = s.Initializer == nil && !s.Const && s.Type != "stretch"
+			declared = append(declared, s.Identifier)
+
+		case *parser.QuantumDeclaration:
+			r.checkReads(stmt, state, analysis, s.Size)
+
+		case *parser.AliasDeclaration:
+			r.checkReads(stmt, state, analysis, s.Value)
+
+		case *parser.GateCall:
+			reads := append(append([]parser.Expression{}, s.Parameters...), s.Qubits...)
+			for _, modifier := range s.Modifiers {
+				reads = append(reads, modifier.Parameters...)
+			}
+			r.checkReads(stmt, state, analysis, reads...)
+
+		case *parser.Reset:
+			r.checkReads(stmt, state, analysis, s.Qubit)
+
+		case *parser.Measurement:
+			r.checkReads(stmt, state, analysis, s.Qubit)
+			r.assign(stmt, s.Target, state, analysis)
+
+		case *parser.Assignment:
+			r.checkReads(stmt, state, analysis, s.Value)
+			if s.Operator != "=" {
+				// Compound assignments read the current value
+				r.checkReads(stmt, state, analysis, s.Target)
+			}
+			r.assign(stmt, s.Target, state, analysis)
+
+		case *parser.IfStatement:
+			r.checkReads(stmt, state, analysis, s.Condition)
+
+			thenState := state.clone()
+			thenDeclared := r.analyzeStatements(s.ThenBody, thenState, analysis)
+			elseState := state.clone()
+			elseDeclared := r.analyzeStatements(s.ElseBody, elseState, analysis)
+
+			r.replace(state, thenState.merge(elseState), append(thenDeclared, elseDeclared...))
+
+		case *parser.ForStatement:
+			r.checkReads(stmt, state, analysis, s.Iterable)
+
+			loopState := state.clone()
+			loopState[s.Variable] = false
+			loopDeclared := r.analyzeStatements(s.Body, loopState, analysis)
+
+			// The loop may execute zero times
+			r.replace(state, state.merge(loopState), append(loopDeclared, s.Variable))
+
+		case *parser.SubroutineDefinition:
+			// Subroutine bodies only see their arguments, which are always initialized
+			r.analyzeStatements(s.Body, make(initState), analysis)
+
+		case *parser.ReturnStatement:
+			r.checkReads(stmt, state, analysis, s.Value)
+
+		case *parser.ExpressionStatement:
+			r.checkReads(stmt, state, analysis, s.Expression)
+
+		case *parser.DelayStatement:
+			r.checkReads(stmt, state, analysis, append([]parser.Expression{s.Duration}, s.Qubits...)...)
+
+		case *parser.BoxStatement:
+			r.checkReads(stmt, state, analysis, s.Duration)
+
+			// The box body always executes, but its declarations are local to it
+			boxState := state.clone()
+			boxDeclared := r.analyzeStatements(s.Body, boxState, analysis)
+			r.replace(state, boxState, boxDeclared)
+
+		case *parser.WhileStatement:
+			r.checkReads(stmt, state, analysis, s.Condition)
+
+			loopState := state.clone()
+			loopDeclared := r.analyzeStatements(s.Body, loopState, analysis)
+
+			r.replace(state, state.merge(loopState), loopDeclared)
+		}
+	}
+
+	return declared
+}
+
+// replace overwrites state with the state after a nested block, keeping the
+// outer entries of names that were local to the block
+func (r *ClassicalReadBeforeAssignmentRule) replace(state, after initState, local []string) {
+	outer := state.clone()
+	for name, uninitialized := range after {
+		state[name] = uninitialized
+	}
+	for _, name := range local {
+		if uninitialized, exists := outer[name]; exists {
+			state[name] = uninitialized
+		} else {
+			delete(state, name)
+		}
+	}
+}
+
+// assign marks the variable written by target as initialized. Index expressions
+// of the target are reads; writing a single element initializes the variable.
+func (r *ClassicalReadBeforeAssignmentRule) assign(stmt parser.Node, target parser.Expression, state initState, analysis *readAnalysis) {
+	switch t := target.(type) {
+	case *parser.Identifier:
+		state[t.Name] = false
+	case *parser.IndexedIdentifier:
+		r.checkReads(stmt, state, analysis, t.Index)
+		state[t.Name] = false
+	case *parser.RangedIdentifier:
+		r.checkReads(stmt, state, analysis, t.Start, t.Step, t.EndIndex)
+		state[t.Name] = false
+	}
+}
+
+// checkReads reports identifiers in exprs that may be uninitialized
+func (r *ClassicalReadBeforeAssignmentRule) checkReads(stmt parser.Node, state initState, analysis *readAnalysis, exprs ...parser.Expression) {
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+
+		astutil.VisitAllNodes(expr, func(node parser.Node) {
+			var name string
+			switch n := node.(type) {
+			case *parser.Identifier:
+				name = n.Name
+			case *parser.IndexedIdentifier:
+				name = n.Name
+			case *parser.RangedIdentifier:
+				name = n.Name
+			default:
+				return
+			}
+
+			if !state[name] {
+				return
+			}
+
+			// Report each variable once per statement
+			if analysis.reported[stmt] == nil {
+				analysis.reported[stmt] = make(map[string]bool)
+			}
+			if analysis.reported[stmt][name] {
+				return
+			}
+			analysis.reported[stmt][name] = true
+
+			violation := r.NewViolationBuilder().
+				WithMessage(fmt.Sprintf("Variable '%s' is read before it is assigned a value.", name)).
+				WithFile(analysis.ctx.File).
+				WithNode(node).
+				WithNodeName(name).
+				AsWarning().
+				Build()
+			analysis.violations = append(analysis.violations, violation)
+		})
+	}
+}
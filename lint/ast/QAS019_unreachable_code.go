@@ -0,0 +1,178 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// UnreachableCodeRule implements QAS019 using AST-based analysis.
+// It reports statements that follow return, break, continue, end or a loop that
+// never terminates, as well as if and while conditions that fold to a constant.
+type UnreachableCodeRule struct {
+	*ASTRuleBase
+}
+
+// NewUnreachableCodeRule creates a new AST-based unreachable code rule
+func NewUnreachableCodeRule() ASTRule {
+	return &UnreachableCodeRule{
+		ASTRuleBase: NewASTRuleBase("QAS019"),
+	}
+}
+
+// reachabilityAnalysis holds the folded constants and findings
+type reachabilityAnalysis struct {
+	ctx        *CheckContext
+	integers   map[string]int64
+	booleans   map[string]bool
+	violations []*Violation
+}
+
+// CheckAST checks every block of the program for unreachable code
+func (r *UnreachableCodeRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	integers := astutil.IntegerConstants(program)
+	analysis := &reachabilityAnalysis{
+		ctx:      ctx,
+		integers: integers,
+		booleans: astutil.BooleanConstants(program, integers),
+	}
+
+	r.checkBlock(program.Statements, analysis)
+
+	return analysis.violations
+}
+
+// checkBlock reports the first unreachable statement of a block and checks nested blocks
+func (r *UnreachableCodeRule) checkBlock(statements []parser.Statement, analysis *reachabilityAnalysis) {
+	for i, stmt := range statements {
+		r.checkStatement(stmt, analysis)
+
+		if reason := r.terminator(stmt, analysis); reason != "" && i+1 < len(statements) {
+			next := statements[i+1]
+			violation := r.NewViolationBuilder().
+				WithMessage(fmt.Sprintf("Unreachable code after %s.", reason)).
+				WithFile(analysis.ctx.File).
+				WithNode(next).
+				WithRelated(stmt, "control never continues past this statement").
+				WithTag(TagUnnecessary).
+				AsWarning().
+				Build()
+			analysis.violations = append(analysis.violations, violation)
+			return
+		}
+	}
+}
+
+// checkStatement reports constant conditions and descends into nested blocks
+func (r *UnreachableCodeRule) checkStatement(stmt parser.Statement, analysis *reachabilityAnalysis) {
+	switch s := stmt.(type) {
+	case *parser.IfStatement:
+		if value, ok := r.evaluate(s.Condition, analysis); ok {
+			message := "Condition is always false; the if branch is unreachable."
+			if value {
+				message = "Condition is always true; the if statement is redundant."
+				if len(s.ElseBody) > 0 {
+					message = "Condition is always true; the else branch is unreachable."
+				}
+			}
+			r.report(s.Condition, message, analysis)
+		}
+		r.checkBlock(s.ThenBody, analysis)
+		r.checkBlock(s.ElseBody, analysis)
+
+	case *parser.WhileStatement:
+		if value, ok := r.evaluate(s.Condition, analysis); ok {
+			if !value {
+				r.report(s.Condition, "Condition is always false; the loop body is unreachable.", analysis)
+			} else if !r.exitsLoop(s.Body) {
+				r.report(s.Condition, "Condition is always true and the loop has no 'break'; it never terminates.", analysis)
+			}
+		}
+		r.checkBlock(s.Body, analysis)
+
+	case *parser.ForStatement:
+		r.checkBlock(s.Body, analysis)
+
+	case *parser.GateDefinition:
+		r.checkBlock(s.Body, analysis)
+
+	case *parser.SubroutineDefinition:
+		r.checkBlock(s.Body, analysis)
+
+	case *parser.BoxStatement:
+		r.checkBlock(s.Body, analysis)
+	}
+}
+
+// terminator describes why control never reaches the statement after stmt,
+// or returns an empty string if it may
+func (r *UnreachableCodeRule) terminator(stmt parser.Statement, analysis *reachabilityAnalysis) string {
+	switch s := stmt.(type) {
+	case *parser.ReturnStatement:
+		return "'return'"
+	case *parser.BreakStatement:
+		return "'break'"
+	case *parser.ContinueStatement:
+		return "'continue'"
+	case *parser.EndStatement:
+		return "'end'"
+	case *parser.WhileStatement:
+		if value, ok := r.evaluate(s.Condition, analysis); ok && value && !r.exitsLoop(s.Body) {
+			return "a loop that never terminates"
+		}
+	}
+	return ""
+}
+
+// exitsLoop reports whether a loop body contains a break that leaves this loop,
+// or a return or end that leaves the program
+func (r *UnreachableCodeRule) exitsLoop(body []parser.Statement) bool {
+	for _, stmt := range body {
+		switch s := stmt.(type) {
+		case *parser.BreakStatement, *parser.ReturnStatement, *parser.EndStatement:
+			return true
+		case *parser.IfStatement:
+			if r.exitsLoop(s.ThenBody) || r.exitsLoop(s.ElseBody) {
+				return true
+			}
+		case *parser.ForStatement, *parser.WhileStatement:
+			// A break in a nested loop only leaves the nested loop; return and end still exit
+			if r.containsExit(stmt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsExit reports whether a statement contains a return or end statement
+func (r *UnreachableCodeRule) containsExit(stmt parser.Statement) bool {
+	found := false
+	astutil.VisitAllNodes(stmt, func(node parser.Node) {
+		switch node.(type) {
+		case *parser.ReturnStatement, *parser.EndStatement:
+			found = true
+		}
+	})
+	return found
+}
+
+// evaluate folds a condition to a constant boolean
+func (r *UnreachableCodeRule) evaluate(condition parser.Expression, analysis *reachabilityAnalysis) (bool, bool) {
+	if condition == nil {
+		return false, false
+	}
+	return astutil.EvaluateBoolean(condition, analysis.integers, analysis.booleans)
+}
+
+// report adds a constant condition violation
+func (r *UnreachableCodeRule) report(node parser.Node, message string, analysis *reachabilityAnalysis) {
+	violation := r.NewViolationBuilder().
+		WithMessage(message).
+		WithFile(analysis.ctx.File).
+		WithNode(node).
+		AsWarning().
+		Build()
+	analysis.violations = append(analysis.violations, violation)
+}
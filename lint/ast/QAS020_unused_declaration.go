@@ -0,0 +1,225 @@
+package ast
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// UnusedDeclarationRule implements QAS020 using AST-based analysis.
+// It reports classical variables, gates, subroutines and includes that are never
+// referenced, each with a fix that deletes the declaration. Global declarations
+// in .inc files are meant for the files including them and are not reported;
+// QAS028 checks library gates against the whole project instead.
+type UnusedDeclarationRule struct {
+	*ASTRuleBase
+}
+
+// NewUnusedDeclarationRule creates a new AST-based unused declaration rule
+func NewUnusedDeclarationRule() ASTRule {
+	return &UnusedDeclarationRule{
+		ASTRuleBase: NewASTRuleBase("QAS020"),
+	}
+}
+
+// CheckAST reports unused classical variables, gates, subroutines and includes
+func (r *UnusedDeclarationRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	usages := astutil.GetIdentifierUsages(program)
+	exported := r.exportedDeclarations(program, ctx.File)
+	reachable := r.reachableDefinitions(program, usages, exported)
+
+	for _, decl := range astutil.FindNodesByType(program, (*parser.ClassicalDeclaration)(nil)) {
+		if exported[decl] || len(usages[decl.Identifier]) > 0 {
+			continue
+		}
+		builder := r.newUnusedViolation(decl, decl.Identifier, ctx,
+			fmt.Sprintf("Variable '%s' is declared but never used.", decl.Identifier))
+		// Initializers that call subroutines or measure have side effects worth keeping
+		if !r.hasSideEffects(decl.Initializer) {
+			builder.WithFix(fmt.Sprintf("Remove unused variable '%s'", decl.Identifier), DeleteNodeEdit(decl, ctx.Content))
+		}
+		violations = append(violations, builder.Build())
+	}
+
+	for _, gateDef := range astutil.FindNodesByType(program, (*parser.GateDefinition)(nil)) {
+		if exported[gateDef] || reachable[gateDef.Name] {
+			continue
+		}
+		violations = append(violations, r.newUnusedViolation(gateDef, gateDef.Name, ctx,
+			fmt.Sprintf("Gate '%s' is defined but never used.", gateDef.Name)).
+			WithFix(fmt.Sprintf("Remove unused gate '%s'", gateDef.Name), DeleteNodeEdit(gateDef, ctx.Content)).
+			Build())
+	}
+
+	for _, subroutine := range astutil.FindNodesByType(program, (*parser.SubroutineDefinition)(nil)) {
+		if exported[subroutine] || reachable[subroutine.Name] {
+			continue
+		}
+		violations = append(violations, r.newUnusedViolation(subroutine, subroutine.Name, ctx,
+			fmt.Sprintf("Subroutine '%s' is defined but never called.", subroutine.Name)).
+			WithFix(fmt.Sprintf("Remove unused subroutine '%s'", subroutine.Name), DeleteNodeEdit(subroutine, ctx.Content)).
+			Build())
+	}
+
+	for _, include := range astutil.FindNodesByType(program, (*parser.Include)(nil)) {
+		provided, ok := r.includedNames(include.Path, ctx.File)
+		if !ok {
+			// Includes that cannot be resolved are left alone
+			continue
+		}
+		used := false
+		for name := range provided {
+			if len(usages[name]) > 0 {
+				used = true
+				break
+			}
+		}
+		if used {
+			continue
+		}
+		violations = append(violations, r.newUnusedViolation(include, include.Path, ctx,
+			fmt.Sprintf("Include '%s' is never used.", include.Path)).
+			WithFix(fmt.Sprintf("Remove unused include '%s'", include.Path), DeleteNodeEdit(include, ctx.Content)).
+			Build())
+	}
+
+	return violations
+}
+
+// exportedDeclarations returns the global statements of an include file, which
+// other files use; nothing is exported from other files
+func (r *UnusedDeclarationRule) exportedDeclarations(program *parser.Program, file string) map[parser.Node]bool {
+	exported := make(map[parser.Node]bool)
+	if !strings.EqualFold(filepath.Ext(file), ".inc") {
+		return exported
+	}
+	for _, stmt := range program.Statements {
+		exported[stmt] = true
+	}
+	return exported
+}
+
+// newUnusedViolation starts an info-level violation for an unused declaration
+func (r *UnusedDeclarationRule) newUnusedViolation(node parser.Node, name string, ctx *CheckContext, message string) *ViolationBuilder {
+	return r.NewViolationBuilder().
+		WithMessage(message).
+		WithFile(ctx.File).
+		WithNode(node).
+		WithNodeName(name).
+		WithTag(TagUnnecessary).
+		AsInfo()
+}
+
+// reachableDefinitions returns the names of gates and subroutines reachable
+// from code outside of gate and subroutine bodies, or from exported
+// definitions. References from a body only count once its definition is
+// reachable, so recursive and mutually recursive definitions that nothing
+// else calls stay unused.
+func (r *UnusedDeclarationRule) reachableDefinitions(program *parser.Program, usages map[string][]parser.Node, exported map[parser.Node]bool) map[string]bool {
+	definitions := make(map[string][]parser.Node)
+	owner := make(map[parser.Node]parser.Node)
+	addDefinition := func(name string, definition parser.Node) {
+		definitions[name] = append(definitions[name], definition)
+		astutil.VisitAllNodes(definition, func(node parser.Node) {
+			owner[node] = definition
+		})
+	}
+	for _, gateDef := range astutil.FindNodesByType(program, (*parser.GateDefinition)(nil)) {
+		addDefinition(gateDef.Name, gateDef)
+	}
+	for _, subroutine := range astutil.FindNodesByType(program, (*parser.SubroutineDefinition)(nil)) {
+		addDefinition(subroutine.Name, subroutine)
+	}
+
+	// Names referenced by each definition body, and names referenced elsewhere
+	references := make(map[parser.Node][]string)
+	reachable := make(map[string]bool)
+	var queue []string
+	reach := func(name string) {
+		if !reachable[name] {
+			reachable[name] = true
+			queue = append(queue, name)
+		}
+	}
+	for name, nodes := range usages {
+		for _, node := range nodes {
+			if definition, inside := owner[node]; inside {
+				references[definition] = append(references[definition], name)
+			} else {
+				reach(name)
+			}
+		}
+	}
+	for name, nodes := range definitions {
+		for _, definition := range nodes {
+			if exported[definition] {
+				reach(name)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, definition := range definitions[name] {
+			for _, referenced := range references[definition] {
+				reach(referenced)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// hasSideEffects reports whether an initializer calls a subroutine or measures
+func (r *UnusedDeclarationRule) hasSideEffects(expr parser.Expression) bool {
+	if expr == nil {
+		return false
+	}
+
+	found := false
+	astutil.VisitAllNodes(expr, func(node parser.Node) {
+		if _, ok := node.(*parser.FunctionCall); ok {
+			found = true
+		}
+	})
+	return found
+}
+
+// includedNames returns the gates and other global names an include file provides.
+// Well-known include files are resolved without reading them; other files are
+// parsed relative to the including file.
+func (r *UnusedDeclarationRule) includedNames(path, file string) (map[string]bool, bool) {
+	names := make(map[string]bool)
+
+	if astutil.StandardIncludes[path] {
+		for name := range astutil.StandardGates {
+			// U and gphase are built into the language
+			if name != "U" && name != "gphase" {
+				names[name] = true
+			}
+		}
+		return names, true
+	}
+
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(file), path))
+	if err != nil {
+		return nil, false
+	}
+
+	result := parser.NewParser().ParseWithErrors(string(content))
+	if result.Program == nil {
+		return nil, false
+	}
+
+	for name := range astutil.GetDeclaredIdentifiers(result.Program) {
+		names[name] = true
+	}
+	return names, true
+}
@@ -0,0 +1,277 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// DeprecatedQASM2ConstructRule implements QAS021 using AST-based analysis.
+// In files declaring OpenQASM 3 it reports constructs carried over from OpenQASM 2
+// and offers the modern equivalent as a fix where the rewrite is mechanical.
+type DeprecatedQASM2ConstructRule struct {
+	*ASTRuleBase
+}
+
+// NewDeprecatedQASM2ConstructRule creates a new AST-based deprecated OpenQASM 2 construct rule
+func NewDeprecatedQASM2ConstructRule() ASTRule {
+	return &DeprecatedQASM2ConstructRule{
+		ASTRuleBase: NewASTRuleBase("QAS021"),
+	}
+}
+
+// qasm2Migration holds what is known about a file while checking it
+type qasm2Migration struct {
+	ctx *CheckContext
+	// registers maps classical register names to their width as written
+	registers map[string]string
+	// legacy is set when the file shows other signs of migration from OpenQASM 2
+	legacy bool
+	// standardGates is set when stdgates.inc or qelib1.inc is included
+	standardGates bool
+	violations    []*Violation
+}
+
+// CheckAST reports OpenQASM 2 constructs in OpenQASM 3 programs
+func (r *DeprecatedQASM2ConstructRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	if program.Version == nil || !strings.HasPrefix(program.Version.Number, "3") {
+		return nil
+	}
+
+	migration := &qasm2Migration{
+		ctx:       ctx,
+		registers: make(map[string]string),
+	}
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *parser.QuantumDeclaration:
+			migration.legacy = migration.legacy || s.Type == "qreg"
+		case *parser.ClassicalDeclaration:
+			migration.legacy = migration.legacy || s.Type == "creg"
+			if width, ok := r.registerWidth(s, migration.ctx.Content); ok {
+				migration.registers[s.Identifier] = width
+			}
+		case *parser.Include:
+			migration.legacy = migration.legacy || s.Path == "qelib1.inc"
+			migration.standardGates = migration.standardGates || astutil.StandardIncludes[s.Path]
+		}
+	}
+
+	r.checkStatements(program.Statements, migration)
+
+	return migration.violations
+}
+
+// checkStatements checks each statement and descends into nested blocks
+func (r *DeprecatedQASM2ConstructRule) checkStatements(statements []parser.Statement, migration *qasm2Migration) {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.QuantumDeclaration:
+			if s.Type == "qreg" {
+				r.reportRegister(s, s.Identifier, "qreg", "qubit", migration)
+			}
+
+		case *parser.ClassicalDeclaration:
+			if s.Type == "creg" {
+				r.reportRegister(s, s.Identifier, "creg", "bit", migration)
+			}
+
+		case *parser.Include:
+			if s.Path == "qelib1.inc" {
+				r.report(s, s.Path, "'qelib1.inc' is the OpenQASM 2 standard library; include 'stdgates.inc' instead.",
+					"Include 'stdgates.inc'", migration, ReplaceNodeEdit(s, `include "stdgates.inc";`))
+			}
+
+		case *parser.GateCall:
+			r.checkGateCall(s, migration)
+
+		case *parser.IfStatement:
+			r.checkCondition(s.Condition, migration)
+			r.checkStatements(s.ThenBody, migration)
+			r.checkStatements(s.ElseBody, migration)
+
+		case *parser.ForStatement:
+			r.checkStatements(s.Body, migration)
+
+		case *parser.WhileStatement:
+			r.checkStatements(s.Body, migration)
+
+		case *parser.GateDefinition:
+			r.checkStatements(s.Body, migration)
+
+		case *parser.SubroutineDefinition:
+			r.checkStatements(s.Body, migration)
+
+		case *parser.BoxStatement:
+			r.checkStatements(s.Body, migration)
+		}
+	}
+}
+
+// reportRegister reports a qreg or creg declaration with a fix that rewrites it
+func (r *DeprecatedQASM2ConstructRule) reportRegister(node parser.Node, name, keyword, declType string, migration *qasm2Migration) {
+	replacement, ok := r.declaration(node, declType, migration.ctx.Content)
+	if !ok {
+		r.report(node, name, fmt.Sprintf("'%s' is deprecated in OpenQASM 3; use '%s' instead.", keyword, declType), "", migration)
+		return
+	}
+	r.report(node, name, fmt.Sprintf("'%s' is deprecated in OpenQASM 3; use '%s' instead.", keyword, replacement),
+		fmt.Sprintf("Replace '%s' with '%s'", keyword, declType), migration, ReplaceNodeEdit(node, replacement))
+}
+
+// checkGateCall reports opaque declarations and the OpenQASM 2 built-in gates
+func (r *DeprecatedQASM2ConstructRule) checkGateCall(call *parser.GateCall, migration *qasm2Migration) {
+	switch call.Name {
+	case "opaque":
+		// OpenQASM 3 has no opaque keyword, so the declaration parses as a call
+		violation := r.NewViolationBuilder().
+			WithMessage("'opaque' gate declarations are not supported in OpenQASM 3; define the gate with a body or provide a 'defcal' calibration.").
+			WithFile(migration.ctx.File).
+			WithNode(call).
+			WithNodeName(call.Name).
+			WithTag(TagDeprecated).
+			AsWarning().
+			Build()
+		migration.violations = append(migration.violations, violation)
+
+	case "CX":
+		// cx is only defined when the standard library is included
+		var edits []TextEdit
+		if migration.standardGates {
+			edits = r.renameGate(call, "cx")
+		}
+		r.report(call, call.Name, "Built-in 'CX' is OpenQASM 2 syntax; use 'cx' from stdgates.inc instead.",
+			"Rename 'CX' to 'cx'", migration, edits...)
+
+	case "U":
+		// U is still built in, so only report it in files migrated from OpenQASM 2
+		if !migration.legacy {
+			return
+		}
+		builder := r.NewViolationBuilder().
+			WithMessage("Built-in 'U' differs from OpenQASM 2 by a global phase; use 'u3' from stdgates.inc to keep OpenQASM 2 semantics.").
+			WithFile(migration.ctx.File).
+			WithNode(call).
+			WithNodeName(call.Name).
+			WithTag(TagDeprecated).
+			AsInfo()
+		if edits := r.renameGate(call, "u3"); migration.standardGates && len(edits) > 0 {
+			builder.WithFix("Rename 'U' to 'u3'", edits...)
+		}
+		migration.violations = append(migration.violations, builder.Build())
+	}
+}
+
+// checkCondition reports comparisons of a whole classical register with an integer
+func (r *DeprecatedQASM2ConstructRule) checkCondition(condition parser.Expression, migration *qasm2Migration) {
+	binary, ok := condition.(*parser.BinaryExpression)
+	if !ok || (binary.Operator != "==" && binary.Operator != "!=") {
+		return
+	}
+
+	identifier, ok := binary.Left.(*parser.Identifier)
+	if !ok {
+		identifier, ok = binary.Right.(*parser.Identifier)
+	}
+	if !ok {
+		return
+	}
+	width, exists := migration.registers[identifier.Name]
+	if !exists {
+		return
+	}
+	if _, ok := binary.Left.(*parser.IntegerLiteral); !ok {
+		if _, ok := binary.Right.(*parser.IntegerLiteral); !ok {
+			return
+		}
+	}
+
+	builder := r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Comparing the whole register '%s' with an integer is OpenQASM 2 syntax; cast it explicitly, e.g. 'uint[n](%s)'.", identifier.Name, identifier.Name)).
+		WithFile(migration.ctx.File).
+		WithNode(identifier).
+		WithNodeName(identifier.Name).
+		WithTag(TagDeprecated).
+		AsWarning()
+	// The register value is unsigned, so cast to an unsigned integer of the same width
+	builder.WithFix(fmt.Sprintf("Cast '%s' to 'uint[%s]'", identifier.Name, width),
+		ReplaceNodeEdit(identifier, fmt.Sprintf("uint[%s](%s)", width, identifier.Name)))
+	migration.violations = append(migration.violations, builder.Build())
+}
+
+// report adds a warning, with a fix when edits are given
+func (r *DeprecatedQASM2ConstructRule) report(node parser.Node, name, message, fixDescription string, migration *qasm2Migration, edits ...TextEdit) {
+	builder := r.NewViolationBuilder().
+		WithMessage(message).
+		WithFile(migration.ctx.File).
+		WithNode(node).
+		WithNodeName(name).
+		WithTag(TagDeprecated).
+		AsWarning()
+	if len(edits) > 0 {
+		builder.WithFix(fixDescription, edits...)
+	}
+	migration.violations = append(migration.violations, builder.Build())
+}
+
+// renameGate returns edits that rename the gate of a call, or none when
+// modifiers precede the gate name
+func (r *DeprecatedQASM2ConstructRule) renameGate(call *parser.GateCall, name string) []TextEdit {
+	if len(call.Modifiers) > 0 {
+		return nil
+	}
+	start := call.Pos()
+	end := start
+	end.Column += len([]rune(call.Name))
+	end.Offset += len([]rune(call.Name))
+	return []TextEdit{{Start: start, End: end, NewText: name}}
+}
+
+// registerWidth returns the width of a creg or bit array declaration as written
+func (r *DeprecatedQASM2ConstructRule) registerWidth(decl *parser.ClassicalDeclaration, content string) (string, bool) {
+	switch {
+	case decl.Type == "creg":
+		_, designator, ok := r.registerSource(decl, content)
+		if !ok || designator == "" {
+			return "", false
+		}
+		return strings.TrimSpace(designator[1 : len(designator)-1]), true
+	case strings.HasPrefix(decl.Type, "bit[") && strings.HasSuffix(decl.Type, "]"):
+		return decl.Type[len("bit[") : len(decl.Type)-1], true
+	case decl.Type == "bit" && decl.Size != nil:
+		if size, ok := decl.Size.(*parser.IntegerLiteral); ok {
+			return fmt.Sprint(size.Value), true
+		}
+	}
+	return "", false
+}
+
+// registerDeclaration matches an OpenQASM 2 register declaration
+var registerDeclaration = regexp.MustCompile(`^(?:qreg|creg)\s+([A-Za-z_][A-Za-z0-9_]*)\s*(\[[^\]]*\])?\s*;$`)
+
+// registerSource splits the source of a qreg or creg declaration into the
+// identifier and the designator as written, including its brackets
+func (r *DeprecatedQASM2ConstructRule) registerSource(node parser.Node, content string) (string, string, bool) {
+	runes := []rune(content)
+	start := clampOffset(node.Pos().Offset, len(runes))
+	end := clampOffset(node.End().Offset, len(runes))
+
+	match := registerDeclaration.FindStringSubmatch(strings.TrimSpace(string(runes[start:end])))
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], match[2], true
+}
+
+// declaration rewrites a qreg or creg declaration with the given OpenQASM 3
+// type, keeping the size expression as written
+func (r *DeprecatedQASM2ConstructRule) declaration(node parser.Node, declType string, content string) (string, bool) {
+	identifier, designator, ok := r.registerSource(node, content)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s%s %s;", declType, designator, identifier), true
+}
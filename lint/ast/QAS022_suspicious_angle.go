@@ -0,0 +1,135 @@
+package ast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// SuspiciousAngleRule implements QAS022 using AST-based analysis.
+// It folds the constant angle parameters of the standard rotation gates and
+// reports angles beyond a full turn, which usually means degrees were passed
+// where radians were expected.
+type SuspiciousAngleRule struct {
+	*ASTRuleBase
+}
+
+// NewSuspiciousAngleRule creates a new AST-based suspicious angle rule
+func NewSuspiciousAngleRule() ASTRule {
+	return &SuspiciousAngleRule{
+		ASTRuleBase: NewASTRuleBase("QAS022"),
+	}
+}
+
+// angleTolerance absorbs rounding when comparing folded angles with 2π
+const angleTolerance = 1e-9
+
+// CheckAST checks the angle parameters of rotation gate calls
+func (r *SuspiciousAngleRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	constants := astutil.FloatConstants(program)
+	userGates := astutil.GateDefinitionsByName(program)
+
+	for _, call := range astutil.FindNodesByType(program, (*parser.GateCall)(nil)) {
+		if _, redefined := userGates[call.Name]; redefined || !r.isRotationGate(call.Name) {
+			continue
+		}
+
+		for _, param := range call.Parameters {
+			value, ok := astutil.EvaluateFloat(param, constants)
+			if !ok || math.Abs(value) <= 2*math.Pi+angleTolerance {
+				continue
+			}
+
+			builder := r.NewViolationBuilder().
+				WithFile(ctx.File).
+				WithNode(param).
+				WithNodeName(call.Name).
+				AsWarning()
+
+			if suggestion, ok := r.degreesAsRadians(value); ok {
+				builder.WithMessage(fmt.Sprintf("Angle %s of '%s' looks like degrees; angles are in radians, did you mean '%s'?",
+					r.formatAngle(value), call.Name, suggestion))
+				// Only rewrite angles written as a plain number
+				if r.isNumericLiteral(param) {
+					builder.WithFix(fmt.Sprintf("Replace with '%s'", suggestion), ReplaceNodeEdit(param, suggestion))
+				}
+			} else {
+				builder.WithMessage(fmt.Sprintf("Angle %s of '%s' exceeds 2π; angles are in radians.",
+					r.formatAngle(value), call.Name))
+			}
+
+			violations = append(violations, builder.Build())
+		}
+	}
+
+	return violations
+}
+
+// isRotationGate reports whether all parameters of a standard gate are angles
+func (r *SuspiciousAngleRule) isRotationGate(name string) bool {
+	// u is the lowercase spelling of U emitted by common toolchains
+	if name == "u" {
+		return true
+	}
+	signature, ok := astutil.StandardGates[name]
+	return ok && signature.Parameters > 0
+}
+
+// degreesAsRadians returns the radian expression for an angle that is a whole
+// multiple of 15 degrees, such as pi/2 for 90. Angles beyond a full turn of
+// degrees would still exceed 2π in radians, so they get no suggestion.
+func (r *SuspiciousAngleRule) degreesAsRadians(value float64) (string, bool) {
+	degrees := math.Round(value)
+	if math.Abs(value-degrees) > angleTolerance || int64(degrees)%15 != 0 || math.Abs(degrees) > 360 {
+		return "", false
+	}
+
+	sign := ""
+	if degrees < 0 {
+		sign = "-"
+		degrees = -degrees
+	}
+
+	numerator, denominator := int64(degrees), int64(180)
+	divisor := gcd(numerator, denominator)
+	numerator /= divisor
+	denominator /= divisor
+
+	expression := "pi"
+	if numerator != 1 {
+		expression = strconv.FormatInt(numerator, 10) + "*pi"
+	}
+	if denominator != 1 {
+		expression += "/" + strconv.FormatInt(denominator, 10)
+	}
+	return sign + expression, true
+}
+
+// isNumericLiteral reports whether an expression is a number, optionally negated
+func (r *SuspiciousAngleRule) isNumericLiteral(expr parser.Expression) bool {
+	switch e := expr.(type) {
+	case *parser.IntegerLiteral, *parser.FloatLiteral:
+		return true
+	case *parser.UnaryExpression:
+		return e.Operator == "-" && r.isNumericLiteral(e.Operand)
+	}
+	return false
+}
+
+// formatAngle formats a folded angle without trailing zeros
+func (r *SuspiciousAngleRule) formatAngle(value float64) string {
+	return strconv.FormatFloat(value, 'g', 6, 64)
+}
+
+// gcd returns the greatest common divisor of two positive integers
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,280 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// InvalidDurationRule implements QAS023 using AST-based analysis.
+// It reports delay and box durations that are not positive or have no unit, and
+// arithmetic that combines durations with unitless numbers or mixes dt with SI units.
+type InvalidDurationRule struct {
+	*ASTRuleBase
+}
+
+// NewInvalidDurationRule creates a new AST-based invalid duration rule
+func NewInvalidDurationRule() ASTRule {
+	return &InvalidDurationRule{
+		ASTRuleBase: NewASTRuleBase("QAS023"),
+	}
+}
+
+// timingKind classifies an expression as a duration, a unitless number or unknown
+type timingKind int
+
+const (
+	kindUnknown timingKind = iota
+	kindNumber
+	kindDuration
+)
+
+// numericTypes lists the declared types that hold unitless numbers
+var numericTypes = map[string]bool{
+	"int":   true,
+	"uint":  true,
+	"float": true,
+	"angle": true,
+}
+
+// durationAnalysis holds the declarations and findings of one program
+type durationAnalysis struct {
+	ctx        *CheckContext
+	durations  map[string]bool
+	units      map[string]string // Unit of duration variables only ever given durations of one unit
+	numbers    map[string]bool
+	constants  map[string]astutil.Duration
+	floats     map[string]float64
+	reported   map[parser.Node]bool
+	violations []*Violation
+}
+
+// CheckAST checks timing instructions and duration arithmetic
+func (r *InvalidDurationRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	floats := astutil.FloatConstants(program)
+	analysis := &durationAnalysis{
+		ctx:       ctx,
+		durations: make(map[string]bool),
+		units:     make(map[string]string),
+		numbers:   make(map[string]bool),
+		constants: astutil.DurationConstants(program, floats),
+		floats:    floats,
+		reported:  make(map[parser.Node]bool),
+	}
+
+	for _, decl := range astutil.FindNodesByType(program, (*parser.ClassicalDeclaration)(nil)) {
+		baseType := strings.SplitN(decl.Type, "[", 2)[0]
+		switch {
+		case astutil.IsDurationType(baseType):
+			analysis.durations[decl.Identifier] = true
+		case numericTypes[baseType]:
+			analysis.numbers[decl.Identifier] = true
+		}
+	}
+
+	r.collectUnits(program, analysis)
+
+	// Arithmetic anywhere in the program
+	for _, binary := range astutil.FindNodesByType(program, (*parser.BinaryExpression)(nil)) {
+		r.classify(binary, analysis)
+	}
+
+	for _, delay := range astutil.FindNodesByType(program, (*parser.DelayStatement)(nil)) {
+		r.checkDuration(delay.Duration, "Delay", analysis)
+	}
+	for _, box := range astutil.FindNodesByType(program, (*parser.BoxStatement)(nil)) {
+		if box.Duration != nil {
+			r.checkDuration(box.Duration, "Box", analysis)
+		}
+	}
+
+	return analysis.violations
+}
+
+// collectUnits records the unit of each duration variable, const or not, from
+// its initializer and the values assigned to it. A variable given durations of
+// different units, or of unknown unit, has no known unit.
+func (r *InvalidDurationRule) collectUnits(program *parser.Program, analysis *durationAnalysis) {
+	var names []string
+	values := make(map[string][]parser.Expression)
+	for _, decl := range astutil.FindNodesByType(program, (*parser.ClassicalDeclaration)(nil)) {
+		if !analysis.durations[decl.Identifier] || decl.Initializer == nil {
+			continue
+		}
+		if _, seen := values[decl.Identifier]; !seen {
+			names = append(names, decl.Identifier)
+		}
+		values[decl.Identifier] = append(values[decl.Identifier], decl.Initializer)
+	}
+	for _, assignment := range astutil.FindNodesByType(program, (*parser.Assignment)(nil)) {
+		if target, ok := assignment.Target.(*parser.Identifier); ok && analysis.durations[target.Name] {
+			values[target.Name] = append(values[target.Name], assignment.Value)
+		}
+	}
+
+	// Variables are resolved in declaration order, so initializers can use the
+	// units of earlier variables
+	for _, name := range names {
+		unit := ""
+		for i, value := range values[name] {
+			kind, valueUnit := r.classify(value, analysis)
+			if kind != kindDuration || valueUnit == "" || (i > 0 && valueUnit != unit) {
+				unit = ""
+				break
+			}
+			unit = valueUnit
+		}
+		if unit != "" {
+			analysis.units[name] = unit
+		}
+	}
+}
+
+// checkDuration reports a delay or box duration that has no unit or is not positive
+func (r *InvalidDurationRule) checkDuration(expr parser.Expression, construct string, analysis *durationAnalysis) {
+	if expr == nil {
+		return
+	}
+
+	kind, _ := r.classify(expr, analysis)
+	if kind == kindNumber {
+		r.report(expr, fmt.Sprintf("%s duration has no unit; use a time unit such as ns, us or dt.", construct), analysis)
+		return
+	}
+
+	value, ok := astutil.EvaluateDuration(expr, analysis.constants, analysis.floats)
+	if !ok {
+		return
+	}
+	switch {
+	case value.Value < 0:
+		r.report(expr, fmt.Sprintf("%s duration must be positive, got %s.", construct, value), analysis)
+	case value.Value == 0:
+		r.report(expr, fmt.Sprintf("%s duration is zero.", construct), analysis)
+	}
+}
+
+// classify returns the kind of an expression and, for durations, whether it is
+// measured in dt or SI units ("" when unknown). Invalid arithmetic is reported at
+// the offending operator and classified as unknown to avoid cascading reports.
+func (r *InvalidDurationRule) classify(expr parser.Expression, analysis *durationAnalysis) (timingKind, string) {
+	switch e := expr.(type) {
+	case *parser.TimingExpression:
+		return kindDuration, astutil.TimingUnit(e)
+
+	case *parser.IntegerLiteral, *parser.FloatLiteral:
+		return kindNumber, ""
+
+	case *parser.Identifier:
+		switch {
+		case analysis.durations[e.Name]:
+			return kindDuration, analysis.units[e.Name]
+		case analysis.numbers[e.Name]:
+			return kindNumber, ""
+		}
+		if _, ok := astutil.EvaluateFloat(e, analysis.floats); ok {
+			return kindNumber, ""
+		}
+
+	case *parser.ParenthesizedExpression:
+		return r.classify(e.Expression, analysis)
+
+	case *parser.UnaryExpression:
+		if e.Operator == "-" || e.Operator == "+" {
+			return r.classify(e.Operand, analysis)
+		}
+
+	case *parser.FunctionCall:
+		if e.Name == "durationof" {
+			return kindDuration, ""
+		}
+
+	case *parser.BinaryExpression:
+		return r.classifyBinary(e, analysis)
+	}
+
+	return kindUnknown, ""
+}
+
+// classifyBinary applies the unit rules of duration arithmetic
+func (r *InvalidDurationRule) classifyBinary(binary *parser.BinaryExpression, analysis *durationAnalysis) (timingKind, string) {
+	left, leftUnit := r.classify(binary.Left, analysis)
+	right, rightUnit := r.classify(binary.Right, analysis)
+
+	switch binary.Operator {
+	case "+", "-":
+		switch {
+		case left == kindDuration && right == kindDuration:
+			if leftUnit != "" && rightUnit != "" && leftUnit != rightUnit {
+				r.reportWarning(binary, "Duration arithmetic mixes dt with SI units; the result depends on the backend sample time.", analysis)
+				return kindUnknown, ""
+			}
+			if leftUnit == "" {
+				return kindDuration, rightUnit
+			}
+			return kindDuration, leftUnit
+		case left == kindDuration && right == kindNumber, left == kindNumber && right == kindDuration:
+			r.report(binary, fmt.Sprintf("Cannot use '%s' between a duration and a unitless number; give the number a time unit.", binary.Operator), analysis)
+			return kindUnknown, ""
+		case left == kindNumber && right == kindNumber:
+			return kindNumber, ""
+		}
+
+	case "*":
+		switch {
+		case left == kindDuration && right == kindDuration:
+			r.report(binary, "Cannot multiply two durations.", analysis)
+			return kindUnknown, ""
+		case left == kindDuration && right == kindNumber:
+			return kindDuration, leftUnit
+		case left == kindNumber && right == kindDuration:
+			return kindDuration, rightUnit
+		case left == kindNumber && right == kindNumber:
+			return kindNumber, ""
+		}
+
+	case "/":
+		switch {
+		case left == kindDuration && right == kindDuration:
+			return kindNumber, ""
+		case left == kindDuration && right == kindNumber:
+			return kindDuration, leftUnit
+		case left == kindNumber && right == kindDuration:
+			r.report(binary, "Cannot divide a unitless number by a duration.", analysis)
+			return kindUnknown, ""
+		case left == kindNumber && right == kindNumber:
+			return kindNumber, ""
+		}
+	}
+
+	return kindUnknown, ""
+}
+
+// report adds an error once per node
+func (r *InvalidDurationRule) report(node parser.Node, message string, analysis *durationAnalysis) {
+	if builder := r.newBuilder(node, message, analysis); builder != nil {
+		analysis.violations = append(analysis.violations, builder.AsError().Build())
+	}
+}
+
+// reportWarning adds a warning once per node
+func (r *InvalidDurationRule) reportWarning(node parser.Node, message string, analysis *durationAnalysis) {
+	if builder := r.newBuilder(node, message, analysis); builder != nil {
+		analysis.violations = append(analysis.violations, builder.AsWarning().Build())
+	}
+}
+
+// newBuilder starts a violation for node, or returns nil if node was already reported
+func (r *InvalidDurationRule) newBuilder(node parser.Node, message string, analysis *durationAnalysis) *ViolationBuilder {
+	if analysis.reported[node] {
+		return nil
+	}
+	analysis.reported[node] = true
+
+	return r.NewViolationBuilder().
+		WithMessage(message).
+		WithFile(analysis.ctx.File).
+		WithNode(node)
+}
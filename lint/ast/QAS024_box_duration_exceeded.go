@@ -0,0 +1,164 @@
+package ast
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// BoxDurationExceededRule implements QAS024 using AST-based analysis.
+// It sums the delays in a box body per qubit. Gate durations depend on the
+// hardware, so the sum is a lower bound; a box is reported when even that lower
+// bound exceeds its declared duration.
+type BoxDurationExceededRule struct {
+	*ASTRuleBase
+}
+
+// NewBoxDurationExceededRule creates a new AST-based box duration exceeded rule
+func NewBoxDurationExceededRule() ASTRule {
+	return &BoxDurationExceededRule{
+		ASTRuleBase: NewASTRuleBase("QAS024"),
+	}
+}
+
+// delayTotals accumulates the delays of a box body per qubit operand
+type delayTotals struct {
+	// global is the total of delays that apply to all qubits
+	global float64
+	// registers holds the total of delays applied to whole registers
+	registers map[string]float64
+	// operands holds the total per operand as written (q or q[0])
+	operands map[string]float64
+	// bases maps each operand to its register name
+	bases map[string]string
+}
+
+// CheckAST checks every box with a statically known duration
+func (r *BoxDurationExceededRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	floats := astutil.FloatConstants(program)
+	constants := astutil.DurationConstants(program, floats)
+
+	for _, box := range astutil.FindNodesByType(program, (*parser.BoxStatement)(nil)) {
+		if box.Duration == nil {
+			continue
+		}
+		declared, ok := astutil.EvaluateDuration(box.Duration, constants, floats)
+		if !ok || declared.Value <= 0 {
+			// Invalid box durations are reported by QAS023
+			continue
+		}
+
+		totals := r.sumDelays(box.Body, declared.Unit, constants, floats)
+		operand, longest := totals.longest()
+		if longest <= declared.Value*(1+1e-9) {
+			continue
+		}
+
+		location := "all qubits"
+		if operand != "" {
+			location = fmt.Sprintf("'%s'", operand)
+		}
+		violation := r.NewViolationBuilder().
+			WithMessage(fmt.Sprintf("Box body takes at least %s on %s, but the box is declared as %s.",
+				astutil.Duration{Value: longest, Unit: declared.Unit}, location, declared)).
+			WithFile(ctx.File).
+			WithNode(box).
+			AsError().
+			Build()
+		violations = append(violations, violation)
+	}
+
+	return violations
+}
+
+// sumDelays adds up the delays that run sequentially in a box body. Delays in
+// another unit, with unknown durations or inside nested blocks are skipped,
+// which keeps the totals a lower bound.
+func (r *BoxDurationExceededRule) sumDelays(body []parser.Statement, unit string, constants map[string]astutil.Duration, floats map[string]float64) *delayTotals {
+	totals := &delayTotals{
+		registers: make(map[string]float64),
+		operands:  make(map[string]float64),
+		bases:     make(map[string]string),
+	}
+
+	for _, stmt := range body {
+		delay, ok := stmt.(*parser.DelayStatement)
+		if !ok {
+			continue
+		}
+		value, ok := astutil.EvaluateDuration(delay.Duration, constants, floats)
+		if !ok || value.Unit != unit || value.Value <= 0 {
+			continue
+		}
+
+		if len(delay.Qubits) == 0 {
+			totals.global += value.Value
+			for operand := range totals.operands {
+				totals.operands[operand] += value.Value
+			}
+			continue
+		}
+
+		for _, qubit := range delay.Qubits {
+			totals.add(qubit, value.Value)
+		}
+	}
+
+	return totals
+}
+
+// add records a delay on one operand
+func (t *delayTotals) add(qubit parser.Expression, value float64) {
+	switch q := qubit.(type) {
+	case *parser.Identifier:
+		// A whole-register delay also delays every element seen so far
+		t.track(q.Name, q.Name)
+		t.registers[q.Name] += value
+		for operand, base := range t.bases {
+			if base == q.Name {
+				t.operands[operand] += value
+			}
+		}
+
+	case *parser.IndexedIdentifier:
+		index, ok := q.Index.(*parser.IntegerLiteral)
+		if !ok {
+			return
+		}
+		operand := fmt.Sprintf("%s[%d]", q.Name, index.Value)
+		t.track(operand, q.Name)
+		t.operands[operand] += value
+	}
+}
+
+// track starts the total of an operand from the delays already applied to it
+// through its register or to all qubits
+func (t *delayTotals) track(operand, base string) {
+	if _, exists := t.operands[operand]; exists {
+		return
+	}
+	t.bases[operand] = base
+	t.operands[operand] = t.global + t.registers[base]
+}
+
+// longest returns the operand with the largest total, or "" when the largest
+// total applies to all qubits
+func (t *delayTotals) longest() (string, float64) {
+	operands := make([]string, 0, len(t.operands))
+	for operand := range t.operands {
+		operands = append(operands, operand)
+	}
+	sort.Strings(operands)
+
+	longestOperand, longest := "", t.global
+	for _, operand := range operands {
+		if t.operands[operand] > longest {
+			longestOperand, longest = operand, t.operands[operand]
+		}
+	}
+	return longestOperand, longest
+}
@@ -0,0 +1,93 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// StretchOutsideTimingRule implements QAS025 using AST-based analysis.
+// Stretch durations are resolved by the compiler, so a stretch may only appear in
+// delay and box durations and in expressions that define other durations.
+type StretchOutsideTimingRule struct {
+	*ASTRuleBase
+}
+
+// NewStretchOutsideTimingRule creates a new AST-based stretch outside timing rule
+func NewStretchOutsideTimingRule() ASTRule {
+	return &StretchOutsideTimingRule{
+		ASTRuleBase: NewASTRuleBase("QAS025"),
+	}
+}
+
+// CheckAST reports references to stretch variables outside timing contexts
+func (r *StretchOutsideTimingRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	stretches := make(map[string]bool)
+	durations := make(map[string]bool)
+	for _, decl := range astutil.FindNodesByType(program, (*parser.ClassicalDeclaration)(nil)) {
+		if decl.Type == "stretch" {
+			stretches[decl.Identifier] = true
+		}
+		if astutil.IsDurationType(decl.Type) {
+			durations[decl.Identifier] = true
+		}
+	}
+	if len(stretches) == 0 {
+		return nil
+	}
+
+	allowed := r.timingContexts(program, durations)
+
+	// Walk the identifiers in source order
+	astutil.VisitAllNodes(program, func(node parser.Node) {
+		identifier, ok := node.(*parser.Identifier)
+		if !ok || !stretches[identifier.Name] || allowed[node] {
+			return
+		}
+		violation := r.NewViolationBuilder().
+			WithMessage(fmt.Sprintf("Stretch '%s' can only be used in timing contexts such as delay and box durations.", identifier.Name)).
+			WithFile(ctx.File).
+			WithNode(identifier).
+			WithNodeName(identifier.Name).
+			AsError().
+			Build()
+		violations = append(violations, violation)
+	})
+
+	return violations
+}
+
+// timingContexts collects the nodes inside expressions where durations are expected
+func (r *StretchOutsideTimingRule) timingContexts(program *parser.Program, durations map[string]bool) map[parser.Node]bool {
+	allowed := make(map[parser.Node]bool)
+	mark := func(expr parser.Expression) {
+		if expr == nil {
+			return
+		}
+		astutil.VisitAllNodes(expr, func(node parser.Node) {
+			allowed[node] = true
+		})
+	}
+
+	astutil.VisitAllNodes(program, func(node parser.Node) {
+		switch n := node.(type) {
+		case *parser.DelayStatement:
+			mark(n.Duration)
+		case *parser.BoxStatement:
+			mark(n.Duration)
+		case *parser.ClassicalDeclaration:
+			if astutil.IsDurationType(n.Type) {
+				mark(n.Initializer)
+			}
+		case *parser.Assignment:
+			if target, ok := n.Target.(*parser.Identifier); ok && durations[target.Name] {
+				mark(n.Value)
+			}
+		}
+	})
+
+	return allowed
+}
@@ -0,0 +1,189 @@
+package ast
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// UnsupportedOnTargetRule implements QAS026 using AST-based analysis.
+// It checks a program against the hardware target profile given with
+// --target: gates outside the native gate set, physical qubits the device
+// does not have, two-qubit gates on uncoupled physical qubits and programs
+// that declare more qubits than the device provides. The rule reports
+// nothing when no target is configured.
+type UnsupportedOnTargetRule struct {
+	*ASTRuleBase
+}
+
+// NewUnsupportedOnTargetRule creates a new AST-based target profile rule
+func NewUnsupportedOnTargetRule() ASTRule {
+	return &UnsupportedOnTargetRule{
+		ASTRuleBase: NewASTRuleBase("QAS026"),
+	}
+}
+
+// CheckAST checks the program against the configured target
+func (r *UnsupportedOnTargetRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	if ctx.Target == nil {
+		return nil
+	}
+
+	var violations []*Violation
+	violations = append(violations, r.checkQubitCount(program, ctx)...)
+
+	userGates := astutil.GateDefinitionsByName(program)
+	astutil.VisitAllNodes(program, func(node parser.Node) {
+		switch n := node.(type) {
+		case *parser.GateCall:
+			if v := r.checkGate(n, userGates, ctx); v != nil {
+				violations = append(violations, v)
+			}
+			if v := r.checkCoupling(n, ctx); v != nil {
+				violations = append(violations, v)
+			}
+		case *parser.Identifier:
+			if v := r.checkPhysicalQubit(n, ctx); v != nil {
+				violations = append(violations, v)
+			}
+		}
+	})
+
+	return violations
+}
+
+// checkQubitCount reports the declaration that takes the program past the
+// number of qubits on the target. Declarations with a size that is not a
+// compile-time constant are not counted.
+func (r *UnsupportedOnTargetRule) checkQubitCount(program *parser.Program, ctx *CheckContext) []*Violation {
+	if ctx.Target.Qubits == 0 {
+		return nil
+	}
+
+	constants := astutil.IntegerConstants(program)
+	total := int64(0)
+	var exceeding *parser.QuantumDeclaration
+	for _, stmt := range program.Statements {
+		decl, ok := stmt.(*parser.QuantumDeclaration)
+		if !ok {
+			continue
+		}
+
+		size := int64(1)
+		if decl.Size != nil {
+			value, ok := astutil.EvaluateInteger(decl.Size, constants)
+			if !ok {
+				continue
+			}
+			size = value
+		}
+
+		total += size
+		if exceeding == nil && total > int64(ctx.Target.Qubits) {
+			exceeding = decl
+		}
+	}
+
+	if exceeding == nil {
+		return nil
+	}
+
+	return []*Violation{r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Program declares %d qubits, but target '%s' has only %d.", total, ctx.Target.Name, ctx.Target.Qubits)).
+		WithFile(ctx.File).
+		WithNode(exceeding).
+		WithNodeName(exceeding.Identifier).
+		AsError().
+		Build()}
+}
+
+// checkGate reports calls to gates the target does not execute natively.
+// Calls to gates defined in the program are skipped; their bodies are checked
+// instead.
+func (r *UnsupportedOnTargetRule) checkGate(call *parser.GateCall, userGates map[string]*parser.GateDefinition, ctx *CheckContext) *Violation {
+	if _, defined := userGates[call.Name]; defined || len(ctx.Target.NativeGates) == 0 {
+		return nil
+	}
+	// Modified gates such as ctrl @ x are distinct operations on the device
+	if len(call.Modifiers) == 0 && ctx.Target.IsNative(call.Name) {
+		return nil
+	}
+
+	return r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Gate '%s' is not native to target '%s' (native gates: %s).",
+			r.describeCall(call), ctx.Target.Name, strings.Join(ctx.Target.NativeGates, ", "))).
+		WithFile(ctx.File).
+		WithNode(call).
+		WithNodeName(call.Name).
+		AsError().
+		Build()
+}
+
+// checkCoupling reports two-qubit gates on physical qubits that are not
+// connected in the coupling map
+func (r *UnsupportedOnTargetRule) checkCoupling(call *parser.GateCall, ctx *CheckContext) *Violation {
+	if len(call.Qubits) != 2 {
+		return nil
+	}
+	control, ok := r.physicalIndex(call.Qubits[0])
+	if !ok {
+		return nil
+	}
+	target, ok := r.physicalIndex(call.Qubits[1])
+	if !ok || control == target || ctx.Target.Coupled(control, target) {
+		return nil
+	}
+
+	message := fmt.Sprintf("Gate '%s' acts on $%d and $%d, which are not coupled on target '%s'.",
+		r.describeCall(call), control, target, ctx.Target.Name)
+	if ctx.Target.Directed && ctx.Target.Coupled(target, control) {
+		message = fmt.Sprintf("Gate '%s' acts on $%d and $%d, but target '%s' only couples them as $%d -> $%d.",
+			r.describeCall(call), control, target, ctx.Target.Name, target, control)
+	}
+
+	return r.NewViolationBuilder().
+		WithMessage(message).
+		WithFile(ctx.File).
+		WithNode(call).
+		WithNodeName(call.Name).
+		AsError().
+		Build()
+}
+
+// checkPhysicalQubit reports physical qubits beyond the size of the target
+func (r *UnsupportedOnTargetRule) checkPhysicalQubit(id *parser.Identifier, ctx *CheckContext) *Violation {
+	index, ok := r.physicalIndex(id)
+	if !ok || ctx.Target.Qubits == 0 || index < ctx.Target.Qubits {
+		return nil
+	}
+
+	return r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Physical qubit '%s' does not exist on target '%s', which has %d qubits.", id.Name, ctx.Target.Name, ctx.Target.Qubits)).
+		WithFile(ctx.File).
+		WithNode(id).
+		WithNodeName(id.Name).
+		AsError().
+		Build()
+}
+
+// physicalIndex returns n for a physical qubit operand $n
+func (r *UnsupportedOnTargetRule) physicalIndex(expr parser.Expression) (int, bool) {
+	id, ok := expr.(*parser.Identifier)
+	if !ok || !strings.HasPrefix(id.Name, "$") {
+		return 0, false
+	}
+	index, err := strconv.Atoi(id.Name[1:])
+	return index, err == nil
+}
+
+// describeCall renders a gate call's modifiers and name, such as "ctrl @ x"
+func (r *UnsupportedOnTargetRule) describeCall(call *parser.GateCall) string {
+	var parts []string
+	for _, modifier := range call.Modifiers {
+		parts = append(parts, modifier.Type)
+	}
+	return strings.Join(append(parts, call.Name), " @ ")
+}
@@ -0,0 +1,318 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// Resource limits configured by the count checks of QAS027
+const (
+	limitQubits        = "qubits"
+	limitTwoQubitGates = "two_qubit_gates"
+	limitDepth         = "depth"
+	limitLoopNesting   = "loop_nesting"
+)
+
+// ResourceLimitExceededRule implements QAS027 using AST-based analysis.
+// It compares the program's qubit count, two-qubit gate count, circuit depth
+// and loop nesting with the maximums of the rule's count checks and reports the
+// statement where each limit is first crossed. Counts are static: statements in
+// loops and branches count once and gate bodies are not expanded.
+type ResourceLimitExceededRule struct {
+	*ASTRuleBase
+}
+
+// NewResourceLimitExceededRule creates a new AST-based resource limit rule
+func NewResourceLimitExceededRule() ASTRule {
+	return &ResourceLimitExceededRule{
+		ASTRuleBase: NewASTRuleBase("QAS027"),
+	}
+}
+
+// CheckAST checks each configured limit
+func (r *ResourceLimitExceededRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+	limits := ctx.Rule.Limits()
+
+	if limit, ok := limits[limitQubits]; ok {
+		violations = append(violations, r.checkQubits(program, limit, ctx)...)
+	}
+
+	operations := r.operations(program.Statements)
+	if limit, ok := limits[limitTwoQubitGates]; ok {
+		violations = append(violations, r.checkTwoQubitGates(operations, limit, ctx)...)
+	}
+	if limit, ok := limits[limitDepth]; ok {
+		violations = append(violations, r.checkDepth(operations, limit, ctx)...)
+	}
+
+	if limit, ok := limits[limitLoopNesting]; ok {
+		for _, stmt := range program.Statements {
+			r.checkLoopNesting(stmt, 0, limit, ctx, &violations)
+		}
+	}
+
+	return violations
+}
+
+// checkQubits reports the declaration or physical qubit that takes the program
+// past the qubit limit. Declarations with a non-constant size are not counted.
+func (r *ResourceLimitExceededRule) checkQubits(program *parser.Program, limit int, ctx *CheckContext) []*Violation {
+	constants := astutil.IntegerConstants(program)
+	total := int64(0)
+	var crossing parser.Node
+
+	count := func(node parser.Node, size int64) {
+		total += size
+		if crossing == nil && total > int64(limit) {
+			crossing = node
+		}
+	}
+
+	physical := make(map[string]bool)
+	astutil.VisitAllNodes(program, func(node parser.Node) {
+		switch n := node.(type) {
+		case *parser.QuantumDeclaration:
+			if n.Size == nil {
+				count(n, 1)
+			} else if size, ok := astutil.EvaluateInteger(n.Size, constants); ok {
+				count(n, size)
+			}
+		case *parser.Identifier:
+			if strings.HasPrefix(n.Name, "$") && !physical[n.Name] {
+				physical[n.Name] = true
+				count(n, 1)
+			}
+		}
+	})
+
+	if crossing == nil {
+		return nil
+	}
+
+	return []*Violation{r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Program uses %d qubits, exceeding the limit of %d.", total, limit)).
+		WithFile(ctx.File).
+		WithNode(crossing).
+		AsWarning().
+		Build()}
+}
+
+// checkTwoQubitGates reports the gate call that takes the program past the
+// two-qubit gate limit
+func (r *ResourceLimitExceededRule) checkTwoQubitGates(operations []parser.Statement, limit int, ctx *CheckContext) []*Violation {
+	total := 0
+	var crossing *parser.GateCall
+
+	for _, op := range operations {
+		call, ok := op.(*parser.GateCall)
+		if !ok || len(call.Qubits) != 2 {
+			continue
+		}
+		total++
+		if crossing == nil && total > limit {
+			crossing = call
+		}
+	}
+
+	if crossing == nil {
+		return nil
+	}
+
+	return []*Violation{r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Program has %d two-qubit gates, exceeding the limit of %d.", total, limit)).
+		WithFile(ctx.File).
+		WithNode(crossing).
+		WithNodeName(crossing.Name).
+		AsWarning().
+		Build()}
+}
+
+// checkDepth schedules each operation one layer after the latest operation on
+// any of its qubits and reports the operation that first goes past the limit
+func (r *ResourceLimitExceededRule) checkDepth(operations []parser.Statement, limit int, ctx *CheckContext) []*Violation {
+	layers := &qubitLayers{
+		registers: make(map[string]int),
+		operands:  make(map[string]int),
+		bases:     make(map[string]string),
+	}
+	depth := 0
+	var crossing parser.Statement
+
+	for _, op := range operations {
+		qubits := r.operationQubits(op)
+		if len(qubits) == 0 {
+			continue
+		}
+
+		layer := 0
+		for _, qubit := range qubits {
+			layer = max(layer, layers.get(qubit))
+		}
+		layer++
+		for _, qubit := range qubits {
+			layers.set(qubit, layer)
+		}
+
+		depth = max(depth, layer)
+		if crossing == nil && depth > limit {
+			crossing = op
+		}
+	}
+
+	if crossing == nil {
+		return nil
+	}
+
+	return []*Violation{r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Circuit depth is %d, exceeding the limit of %d.", depth, limit)).
+		WithFile(ctx.File).
+		WithNode(crossing).
+		AsWarning().
+		Build()}
+}
+
+// checkLoopNesting reports loops nested one level deeper than the limit.
+// Subroutine bodies start again at the top level.
+func (r *ResourceLimitExceededRule) checkLoopNesting(stmt parser.Statement, nesting, limit int, ctx *CheckContext, violations *[]*Violation) {
+	var bodies [][]parser.Statement
+
+	switch s := stmt.(type) {
+	case *parser.ForStatement:
+		nesting++
+		r.reportLoopNesting(s, nesting, limit, ctx, violations)
+		bodies = append(bodies, s.Body)
+	case *parser.WhileStatement:
+		nesting++
+		r.reportLoopNesting(s, nesting, limit, ctx, violations)
+		bodies = append(bodies, s.Body)
+	case *parser.IfStatement:
+		bodies = append(bodies, s.ThenBody, s.ElseBody)
+	case *parser.BoxStatement:
+		bodies = append(bodies, s.Body)
+	case *parser.SubroutineDefinition:
+		nesting = 0
+		bodies = append(bodies, s.Body)
+	}
+
+	for _, body := range bodies {
+		for _, child := range body {
+			r.checkLoopNesting(child, nesting, limit, ctx, violations)
+		}
+	}
+}
+
+// reportLoopNesting reports a loop at the first nesting level past the limit
+func (r *ResourceLimitExceededRule) reportLoopNesting(loop parser.Statement, nesting, limit int, ctx *CheckContext, violations *[]*Violation) {
+	if nesting != limit+1 {
+		return
+	}
+	*violations = append(*violations, r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Loop is nested %d levels deep, exceeding the limit of %d.", nesting, limit)).
+		WithFile(ctx.File).
+		WithNode(loop).
+		AsWarning().
+		Build())
+}
+
+// operations flattens the quantum operations of the program in source order,
+// descending into control flow and boxes but not into gate or subroutine bodies
+func (r *ResourceLimitExceededRule) operations(statements []parser.Statement) []parser.Statement {
+	var operations []parser.Statement
+
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.GateCall, *parser.Measurement, *parser.Reset:
+			operations = append(operations, s)
+		case *parser.IfStatement:
+			operations = append(operations, r.operations(s.ThenBody)...)
+			operations = append(operations, r.operations(s.ElseBody)...)
+		case *parser.ForStatement:
+			operations = append(operations, r.operations(s.Body)...)
+		case *parser.WhileStatement:
+			operations = append(operations, r.operations(s.Body)...)
+		case *parser.BoxStatement:
+			operations = append(operations, r.operations(s.Body)...)
+		}
+	}
+
+	return operations
+}
+
+// operationQubits returns the qubit operands of an operation
+func (r *ResourceLimitExceededRule) operationQubits(op parser.Statement) []parser.Expression {
+	switch o := op.(type) {
+	case *parser.GateCall:
+		return o.Qubits
+	case *parser.Measurement:
+		return []parser.Expression{o.Qubit}
+	case *parser.Reset:
+		return []parser.Expression{o.Qubit}
+	}
+	return nil
+}
+
+// qubitLayers tracks the layer of the latest operation on each qubit operand
+type qubitLayers struct {
+	// registers holds the layer of the latest operation on a whole register
+	registers map[string]int
+	// operands holds the layer per register element as written (q[0])
+	operands map[string]int
+	// bases maps each element to its register name
+	bases map[string]string
+}
+
+// get returns the layer of the latest operation that touched an operand
+func (l *qubitLayers) get(qubit parser.Expression) int {
+	element, base, ok := l.element(qubit)
+	if ok {
+		return max(l.registers[base], l.operands[element])
+	}
+
+	layer := l.registers[base]
+	for operand, operandBase := range l.bases {
+		if operandBase == base {
+			layer = max(layer, l.operands[operand])
+		}
+	}
+	return layer
+}
+
+// set records an operation on an operand at the given layer
+func (l *qubitLayers) set(qubit parser.Expression, layer int) {
+	element, base, ok := l.element(qubit)
+	if ok {
+		l.bases[element] = base
+		l.operands[element] = layer
+		return
+	}
+
+	// An operation on a whole register occupies all of its elements
+	l.registers[base] = layer
+	for operand, operandBase := range l.bases {
+		if operandBase == base {
+			l.operands[operand] = layer
+		}
+	}
+}
+
+// element returns the register element an operand refers to, such as q[0].
+// ok is false for whole registers and for indices that are not literals,
+// which are treated as touching the whole register.
+func (l *qubitLayers) element(qubit parser.Expression) (string, string, bool) {
+	switch q := qubit.(type) {
+	case *parser.IndexedIdentifier:
+		if index, ok := q.Index.(*parser.IntegerLiteral); ok {
+			return fmt.Sprintf("%s[%d]", q.Name, index.Value), q.Name, true
+		}
+		return "", q.Name, false
+	case *parser.RangedIdentifier:
+		return "", q.Name, false
+	case *parser.Identifier:
+		return "", q.Name, false
+	}
+	return "", "", false
+}
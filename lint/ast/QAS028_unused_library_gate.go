@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// UnusedLibraryGateRule implements QAS028 as a project rule.
+// A library is a linted file that another file of the project includes. Its
+// global gate definitions are reported when no file of the project, including
+// the library itself, calls them. Libraries no file includes are left alone,
+// since the files using them are not part of the lint run.
+type UnusedLibraryGateRule struct {
+	*ASTRuleBase
+}
+
+// NewUnusedLibraryGateRule creates a new AST-based unused library gate rule
+func NewUnusedLibraryGateRule() ASTRule {
+	return &UnusedLibraryGateRule{
+		ASTRuleBase: NewASTRuleBase("QAS028"),
+	}
+}
+
+// CheckAST reports nothing; library gates are checked with the whole project
+func (r *UnusedLibraryGateRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	return nil
+}
+
+// CheckProject reports the gates of included libraries that no file calls
+func (r *UnusedLibraryGateRule) CheckProject(project *Project, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	// Calls from inside a gate's own definition are not part of the summaries
+	called := make(map[string]bool)
+	for _, file := range project.Files {
+		for _, name := range file.Calls {
+			called[name] = true
+		}
+	}
+
+	for _, file := range project.Files {
+		if !file.Linted || len(project.Includers(file)) == 0 {
+			continue
+		}
+
+		for _, gate := range file.Gates {
+			if called[gate.Name] {
+				continue
+			}
+			violations = append(violations, r.NewViolationBuilder().
+				WithMessage(fmt.Sprintf("Gate '%s' is defined in a library but never used in the project.", gate.Name)).
+				WithFile(file.Path).
+				WithNode(gate).
+				WithNodeName(gate.Name).
+				WithTag(TagUnnecessary).
+				AsInfo().
+				Build())
+		}
+	}
+
+	return violations
+}
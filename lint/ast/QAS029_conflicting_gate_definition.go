@@ -0,0 +1,170 @@
+package ast
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// ConflictingGateDefinitionRule implements QAS029 as a project rule.
+// For each linted file it collects the global gate definitions reachable
+// through its include statements, in include order, and reports the include
+// statement that brings in a gate already defined with a different number of
+// parameters or qubits through an earlier include statement. Well-known include
+// files define the standard gates.
+type ConflictingGateDefinitionRule struct {
+	*ASTRuleBase
+}
+
+// NewConflictingGateDefinitionRule creates a new AST-based conflicting gate definition rule
+func NewConflictingGateDefinitionRule() ASTRule {
+	return &ConflictingGateDefinitionRule{
+		ASTRuleBase: NewASTRuleBase("QAS029"),
+	}
+}
+
+// includedGate is a gate definition reached through an include statement
+type includedGate struct {
+	name      string
+	signature astutil.GateSignature
+	source    string       // Path of the defining file, or the well-known include
+	file      *ProjectFile // Defining file, nil for well-known includes
+	node      *ProjectGate // Definition, nil for well-known includes
+}
+
+// CheckAST reports nothing; includes are checked with the whole project
+func (r *ConflictingGateDefinitionRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	return nil
+}
+
+// CheckProject reports include statements bringing in conflicting gate definitions
+func (r *ConflictingGateDefinitionRule) CheckProject(project *Project, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+
+	for _, file := range project.Files {
+		if !file.Linted {
+			continue
+		}
+
+		defined := make(map[string]includedGate)
+		definedBy := make(map[string]*ProjectInclude)
+		visited := make(map[*ProjectFile]bool)
+		for _, include := range file.Includes {
+			reported := make(map[string]bool)
+			for _, gate := range r.includedGates(include, visited) {
+				first, exists := defined[gate.name]
+				if !exists {
+					defined[gate.name] = gate
+					definedBy[gate.name] = include
+					continue
+				}
+				// Conflicts within one included file are that file's own concern
+				if definedBy[gate.name] == include || first.signature == gate.signature || reported[gate.name] {
+					continue
+				}
+				reported[gate.name] = true
+				violations = append(violations, r.newViolation(file, include, first, gate))
+			}
+		}
+	}
+
+	return violations
+}
+
+// includedGates returns the gates an include statement defines, directly or
+// through nested includes, skipping files visited before
+func (r *ConflictingGateDefinitionRule) includedGates(include *ProjectInclude, visited map[*ProjectFile]bool) []includedGate {
+	if include.File == nil {
+		if !astutil.StandardIncludes[include.Path] {
+			return nil
+		}
+		return r.standardGates(include.Path)
+	}
+
+	file := include.File
+	if visited[file] {
+		return nil
+	}
+	visited[file] = true
+
+	// Gates and include statements are visited in source order
+	var gates []includedGate
+	next := 0
+	for i, nested := range file.Includes {
+		for ; next < len(file.Gates) && file.Gates[next].Includes <= i; next++ {
+			gates = append(gates, r.definedGate(file, file.Gates[next]))
+		}
+		gates = append(gates, r.includedGates(nested, visited)...)
+	}
+	for ; next < len(file.Gates); next++ {
+		gates = append(gates, r.definedGate(file, file.Gates[next]))
+	}
+	return gates
+}
+
+// definedGate returns a gate defined in a project file
+func (r *ConflictingGateDefinitionRule) definedGate(file *ProjectFile, gate *ProjectGate) includedGate {
+	return includedGate{
+		name:      gate.Name,
+		signature: astutil.GateSignature{Parameters: gate.Parameters, Qubits: gate.Qubits},
+		source:    file.Path,
+		file:      file,
+		node:      gate,
+	}
+}
+
+// standardGates returns the gates a well-known include file defines, sorted by name
+func (r *ConflictingGateDefinitionRule) standardGates(path string) []includedGate {
+	var gates []includedGate
+	for name, signature := range astutil.StandardGates {
+		// U and gphase are built into the language
+		if name == "U" || name == "gphase" {
+			continue
+		}
+		gates = append(gates, includedGate{name: name, signature: signature, source: path})
+	}
+	slices.SortFunc(gates, func(a, b includedGate) int { return strings.Compare(a.name, b.name) })
+	return gates
+}
+
+// newViolation creates the violation for an include statement bringing in a
+// definition of gate that conflicts with the earlier definition first
+func (r *ConflictingGateDefinitionRule) newViolation(file *ProjectFile, include *ProjectInclude, first, gate includedGate) *Violation {
+	builder := r.NewViolationBuilder().
+		WithMessage(fmt.Sprintf("Gate '%s' is defined with %s in '%s' but with %s in '%s'.",
+			gate.name, describeSignature(gate.signature), r.displayPath(file, gate),
+			describeSignature(first.signature), r.displayPath(file, first))).
+		WithFile(file.Path).
+		WithNode(include).
+		WithNodeName(gate.name).
+		AsError()
+
+	for _, definition := range []includedGate{first, gate} {
+		if definition.node != nil {
+			builder.WithRelatedInFile(definition.file.Path, definition.node,
+				fmt.Sprintf("'%s' defined here with %s", definition.name, describeSignature(definition.signature)))
+		}
+	}
+	return builder.Build()
+}
+
+// displayPath returns the path of the file defining a gate relative to the
+// file the violation is reported in
+func (r *ConflictingGateDefinitionRule) displayPath(file *ProjectFile, gate includedGate) string {
+	if gate.file == nil {
+		return gate.source
+	}
+	if rel, err := filepath.Rel(filepath.Dir(file.Path), gate.source); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return gate.source
+}
+
+// describeSignature formats the parameter and qubit counts of a gate signature
+func describeSignature(signature astutil.GateSignature) string {
+	return pluralize(signature.Parameters, "parameter") + " and " + pluralize(signature.Qubits, "qubit")
+}
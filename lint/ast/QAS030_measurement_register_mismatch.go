@@ -0,0 +1,232 @@
+package ast
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// MeasurementRegisterMismatchRule implements QAS030 using AST-based analysis.
+// It resolves the declared TypeInfo of both sides of measure q -> c, c = measure q
+// and bit[n] c = measure q, and reports measuring anything but qubits, storing
+// results in anything but bits and widths that differ. Indexed operands count
+// one element, index sets their elements and slices the elements of their
+// range. Widths that are not compile-time constants are not compared, and
+// subroutine and gate bodies are skipped, since their parameters shadow globals.
+type MeasurementRegisterMismatchRule struct {
+	*ASTRuleBase
+}
+
+// NewMeasurementRegisterMismatchRule creates a new AST-based measurement register mismatch rule
+func NewMeasurementRegisterMismatchRule() ASTRule {
+	return &MeasurementRegisterMismatchRule{
+		ASTRuleBase: NewASTRuleBase("QAS030"),
+	}
+}
+
+// measureOperand is the measured or assigned side of a measurement
+type measureOperand struct {
+	name  string
+	info  *parser.TypeInfo // Declared type of the whole variable
+	kind  string           // Kind of the selected elements
+	width int              // Number of selected elements, 0 when unknown
+}
+
+// CheckAST checks the measurements of the program against the declared types
+func (r *MeasurementRegisterMismatchRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+	constants := astutil.IntegerConstants(program)
+	r.checkBlock(program.Statements, make(map[string]*parser.TypeInfo), constants, ctx, &violations)
+	return violations
+}
+
+// checkBlock checks the measurements of a block; types holds the declarations
+// visible from the enclosing blocks and is not modified
+func (r *MeasurementRegisterMismatchRule) checkBlock(statements []parser.Statement, types map[string]*parser.TypeInfo, constants map[string]int64, ctx *CheckContext, violations *[]*Violation) {
+	scope := maps.Clone(types)
+
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *parser.QuantumDeclaration:
+			info, ok := astutil.QuantumTypeInfo(s, constants)
+			r.declare(scope, s.Identifier, info, ok)
+		case *parser.ClassicalDeclaration:
+			info, ok := astutil.ClassicalTypeInfo(s, constants)
+			r.declare(scope, s.Identifier, info, ok)
+			call, ok := s.Initializer.(*parser.FunctionCall)
+			if !ok || call.Name != "measure" || len(call.Arguments) != 1 {
+				continue
+			}
+			if info, declared := scope[s.Identifier]; declared {
+				target := measureOperand{name: s.Identifier, info: info, kind: info.Kind, width: info.ArraySize()}
+				r.check(s, call.Arguments[0], target, scope, constants, ctx, violations)
+			}
+		case *parser.Measurement:
+			if s.Target == nil {
+				continue
+			}
+			if target, ok := r.resolve(s.Target, scope, constants); ok {
+				r.check(s, s.Qubit, target, scope, constants, ctx, violations)
+			}
+		case *parser.ForStatement:
+			body := maps.Clone(scope)
+			delete(body, s.Variable)
+			r.checkBlock(s.Body, body, constants, ctx, violations)
+		case *parser.WhileStatement:
+			r.checkBlock(s.Body, scope, constants, ctx, violations)
+		case *parser.IfStatement:
+			r.checkBlock(s.ThenBody, scope, constants, ctx, violations)
+			r.checkBlock(s.ElseBody, scope, constants, ctx, violations)
+		case *parser.BoxStatement:
+			r.checkBlock(s.Body, scope, constants, ctx, violations)
+		}
+	}
+}
+
+// declare records the type of a declaration, forgetting earlier declarations
+// of the name when the type cannot be resolved
+func (r *MeasurementRegisterMismatchRule) declare(scope map[string]*parser.TypeInfo, name string, info *parser.TypeInfo, ok bool) {
+	if !ok {
+		delete(scope, name)
+		return
+	}
+	scope[name] = info
+}
+
+// check reports a measurement of source into target with mismatched types or widths
+func (r *MeasurementRegisterMismatchRule) check(node parser.Node, source parser.Expression, target measureOperand, scope map[string]*parser.TypeInfo, constants map[string]int64, ctx *CheckContext, violations *[]*Violation) {
+	measured, ok := r.resolve(source, scope, constants)
+	if !ok {
+		// Undeclared identifiers are reported by QAS002
+		return
+	}
+
+	var message string
+	switch {
+	case measured.kind != "qubit":
+		message = fmt.Sprintf("Cannot measure '%s' of type %s; only qubits can be measured.", measured.name, measured.info)
+	case target.kind != "bit":
+		message = fmt.Sprintf("Cannot store a measurement in '%s' of type %s; measurement results are bits.", target.name, target.info)
+	case measured.width > 0 && target.width > 0 && measured.width != target.width:
+		message = fmt.Sprintf("Measuring %s of '%s' into %s of '%s'; the widths must match.",
+			pluralize(measured.width, "qubit"), measured.name, pluralize(target.width, "bit"), target.name)
+	default:
+		return
+	}
+
+	*violations = append(*violations, r.NewViolationBuilder().
+		WithMessage(message).
+		WithFile(ctx.File).
+		WithNode(node).
+		WithNodeName(target.name).
+		AsError().
+		Build())
+}
+
+// resolve returns the declared type and the selected width of a measurement operand
+func (r *MeasurementRegisterMismatchRule) resolve(expr parser.Expression, scope map[string]*parser.TypeInfo, constants map[string]int64) (measureOperand, bool) {
+	switch e := expr.(type) {
+	case *parser.Identifier:
+		if strings.HasPrefix(e.Name, "$") {
+			// Physical qubits are not declared
+			info := &parser.TypeInfo{Kind: "qubit"}
+			return measureOperand{name: e.Name, info: info, kind: info.Kind, width: 1}, true
+		}
+		info, ok := scope[e.Name]
+		if !ok {
+			return measureOperand{}, false
+		}
+		return measureOperand{name: e.Name, info: info, kind: info.Kind, width: info.ArraySize()}, true
+
+	case *parser.IndexedIdentifier:
+		info, ok := scope[e.Name]
+		if !ok {
+			return measureOperand{}, false
+		}
+		operand := measureOperand{name: e.Name, info: info, kind: r.elementKind(info)}
+		switch index := e.Index.(type) {
+		case *parser.SetExpression:
+			operand.width = len(index.Elements)
+		case *parser.Identifier:
+			// The parser keeps index sets such as {0, 2} as identifiers
+			if strings.HasPrefix(index.Name, "{") && strings.HasSuffix(index.Name, "}") {
+				operand.width = strings.Count(index.Name, ",") + 1
+			} else {
+				operand.width = 1
+			}
+		case *parser.RangeExpression:
+			operand.width = r.rangeWidth(index.Start, index.Step, index.EndIndex, info, constants)
+		default:
+			operand.width = 1
+		}
+		return operand, true
+
+	case *parser.RangedIdentifier:
+		info, ok := scope[e.Name]
+		if !ok {
+			return measureOperand{}, false
+		}
+		return measureOperand{
+			name:  e.Name,
+			info:  info,
+			kind:  r.elementKind(info),
+			width: r.rangeWidth(e.Start, e.Step, e.EndIndex, info, constants),
+		}, true
+	}
+
+	return measureOperand{}, false
+}
+
+// elementKind returns the kind of the elements selected by indexing a
+// variable; indexing sized integers and angles selects their bits
+func (r *MeasurementRegisterMismatchRule) elementKind(info *parser.TypeInfo) string {
+	if !info.IsArray() && info.BitWidth > 0 {
+		return "bit"
+	}
+	return info.Kind
+}
+
+// rangeWidth returns the number of elements of an inclusive range, or 0 when
+// it is not known. Omitted bounds default to the whole register and negative
+// bounds count from its end.
+func (r *MeasurementRegisterMismatchRule) rangeWidth(start, step, end parser.Expression, info *parser.TypeInfo, constants map[string]int64) int {
+	size := int64(info.ArraySize())
+	if !info.IsArray() {
+		size = int64(info.BitWidth)
+	}
+
+	bound := func(expr parser.Expression, omitted int64) (int64, bool) {
+		if expr == nil {
+			return omitted, size > 0
+		}
+		value, ok := astutil.EvaluateInteger(expr, constants)
+		if ok && value < 0 {
+			value += size
+			ok = size > 0
+		}
+		return value, ok
+	}
+
+	first, ok := bound(start, 0)
+	if !ok {
+		return 0
+	}
+	last, ok := bound(end, size-1)
+	if !ok {
+		return 0
+	}
+	stride := int64(1)
+	if step != nil {
+		if stride, ok = astutil.EvaluateInteger(step, constants); !ok || stride == 0 {
+			return 0
+		}
+	}
+
+	if (last-first)*stride < 0 {
+		return 0
+	}
+	return int((last-first)/stride) + 1
+}
@@ -0,0 +1,80 @@
+package ast
+
+import (
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// DeleteNodeEdit returns an edit that removes a statement from content. When the
+// statement is the only code on its lines, the whole lines are removed so that
+// no blank line is left behind.
+func DeleteNodeEdit(node parser.Node, content string) TextEdit {
+	runes := []rune(content)
+	start := clampOffset(node.Pos().Offset, len(runes))
+	end := clampOffset(node.End().Offset, len(runes))
+
+	lineStart := start
+	for lineStart > 0 && isBlank(runes[lineStart-1]) {
+		lineStart--
+	}
+	lineEnd := end
+	for lineEnd < len(runes) && isBlank(runes[lineEnd]) {
+		lineEnd++
+	}
+
+	ownsLines := (lineStart == 0 || runes[lineStart-1] == '\n') &&
+		(lineEnd == len(runes) || runes[lineEnd] == '\n' || runes[lineEnd] == '\r')
+	if ownsLines {
+		start = lineStart
+		end = lineEnd
+		if end < len(runes) && runes[end] == '\r' {
+			end++
+		}
+		if end < len(runes) && runes[end] == '\n' {
+			end++
+		}
+	}
+
+	return TextEdit{
+		Start: positionAt(runes, start),
+		End:   positionAt(runes, end),
+	}
+}
+
+// ReplaceNodeEdit returns an edit that replaces the source of node with newText
+func ReplaceNodeEdit(node parser.Node, newText string) TextEdit {
+	return TextEdit{
+		Start:   node.Pos(),
+		End:     node.End(),
+		NewText: newText,
+	}
+}
+
+// isBlank reports whether r is a space or tab
+func isBlank(r rune) bool {
+	return r == ' ' || r == '\t'
+}
+
+// clampOffset keeps an offset within the content
+func clampOffset(offset, length int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > length {
+		return length
+	}
+	return offset
+}
+
+// positionAt converts a rune offset to a position with 1-based line and column
+func positionAt(runes []rune, offset int) parser.Position {
+	pos := parser.Position{Line: 1, Column: 1, Offset: offset}
+	for _, r := range runes[:offset] {
+		if r == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+	return pos
+}
@@ -2,51 +2,57 @@
 package ast
 
 import (
+	"github.com/orangekame3/qasmtools/lint/core"
 	"github.com/orangekame3/qasmtools/parser"
 )
 
-// Severity represents the severity level of a lint rule violation
-type Severity string
-
-const (
-	SeverityError   Severity = "error"
-	SeverityWarning Severity = "warning"
-	SeverityInfo    Severity = "info"
+// The rule data model is defined in the core package and shared with the lint
+// engine, so violations built here reach every consumer without conversion.
+type (
+	Severity        = core.Severity
+	Rule            = core.Rule
+	Violation       = core.Violation
+	Tag             = core.Tag
+	RelatedLocation = core.RelatedLocation
+	Fix             = core.Fix
+	TextEdit        = core.TextEdit
+	CheckContext    = core.CheckContext
+	Target          = core.Target
+	Project         = core.Project
+	ProjectFile     = core.ProjectFile
+	ProjectGate     = core.ProjectGate
+	ProjectInclude  = core.ProjectInclude
 )
 
-// Rule represents minimal rule information for violations
-type Rule struct {
-	ID string
-}
-
-// Violation represents a rule violation from AST analysis
-type Violation struct {
-	Rule     *Rule
-	Message  string
-	File     string
-	Line     int
-	Column   int
-	Severity Severity
-	NodeName string
-}
+const (
+	SeverityError   = core.SeverityError
+	SeverityWarning = core.SeverityWarning
+	SeverityInfo    = core.SeverityInfo
 
-// CheckContext provides context for AST rule checking
-type CheckContext struct {
-	File     string
-	Content  string
-	Program  *parser.Program
-	UsageMap map[string][]parser.Node
-}
+	TagUnnecessary = core.TagUnnecessary
+	TagDeprecated  = core.TagDeprecated
+)
 
 // ASTRule interface for AST-based lint rules
 type ASTRule interface {
 	// ID returns the unique identifier for this rule (e.g., "QAS001")
 	ID() string
-	
+
 	// CheckAST performs the rule check on the AST and returns any violations
 	CheckAST(program *parser.Program, ctx *CheckContext) []*Violation
 }
 
+// ProjectRule is implemented by AST rules that check all files of a lint run
+// together with the files they include. Their CheckAST reports nothing, since a
+// single file lacks the files that include or use it.
+type ProjectRule interface {
+	ASTRule
+
+	// CheckProject performs the rule check on a project and returns any
+	// violations, each with the file it is reported in
+	CheckProject(project *Project, ctx *CheckContext) []*Violation
+}
+
 // ASTRuleBase provides common functionality for AST-based rules
 type ASTRuleBase struct {
 	ruleID string
@@ -91,13 +97,18 @@ func (r *ASTRuleBase) NewViolationBuilder() *ViolationBuilder {
 
 // ViolationBuilder provides a fluent interface for building violations from AST rules
 type ViolationBuilder struct {
-	rule     *ASTRuleBase
-	message  string
-	file     string
-	line     int
-	column   int
-	nodeName string
-	severity Severity
+	rule      *ASTRuleBase
+	message   string
+	file      string
+	line      int
+	column    int
+	endLine   int
+	endColumn int
+	nodeName  string
+	severity  Severity
+	tags      []Tag
+	related   []RelatedLocation
+	fix       *Fix
 }
 
 // WithMessage sets the violation message
@@ -119,11 +130,17 @@ func (vb *ViolationBuilder) WithPosition(pos parser.Position) *ViolationBuilder
 	return vb
 }
 
-// WithNode sets position information from an AST node
+// WithNode sets the start and, when known, the end position from an AST node
 func (vb *ViolationBuilder) WithNode(node parser.Node) *ViolationBuilder {
 	pos := node.Pos()
 	vb.line = pos.Line
 	vb.column = pos.Column
+	vb.endLine = 0
+	vb.endColumn = 0
+	if end := node.End(); end.Line > 0 && (end.Line > pos.Line || end.Line == pos.Line && end.Column >= pos.Column) {
+		vb.endLine = end.Line
+		vb.endColumn = end.Column
+	}
 	return vb
 }
 
@@ -145,6 +162,44 @@ func (vb *ViolationBuilder) WithNodeName(nodeName string) *ViolationBuilder {
 	return vb
 }
 
+// WithTag marks the violation with a tag such as TagUnnecessary
+func (vb *ViolationBuilder) WithTag(tag Tag) *ViolationBuilder {
+	vb.tags = append(vb.tags, tag)
+	return vb
+}
+
+// WithRelated adds a related location taken from an AST node in the same file
+func (vb *ViolationBuilder) WithRelated(node parser.Node, message string) *ViolationBuilder {
+	pos := node.Pos()
+	vb.related = append(vb.related, RelatedLocation{
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Message: message,
+	})
+	return vb
+}
+
+// WithRelatedInFile adds a related location taken from an AST node in another file
+func (vb *ViolationBuilder) WithRelatedInFile(file string, node parser.Node, message string) *ViolationBuilder {
+	pos := node.Pos()
+	vb.related = append(vb.related, RelatedLocation{
+		File:    file,
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Message: message,
+	})
+	return vb
+}
+
+// WithFix attaches an automatic correction
+func (vb *ViolationBuilder) WithFix(description string, edits ...TextEdit) *ViolationBuilder {
+	vb.fix = &Fix{
+		Description: description,
+		Edits:       edits,
+	}
+	return vb
+}
+
 // WithSeverity sets the severity level
 func (vb *ViolationBuilder) WithSeverity(severity Severity) *ViolationBuilder {
 	vb.severity = severity
@@ -171,5 +226,16 @@ func (vb *ViolationBuilder) AsInfo() *ViolationBuilder {
 
 // Build creates the violation
 func (vb *ViolationBuilder) Build() *Violation {
-	return vb.rule.CreateViolation(vb.message, vb.file, vb.line, vb.column, vb.nodeName, vb.severity)
-}
\ No newline at end of file
+	violation := vb.rule.CreateViolation(vb.message, vb.file, vb.line, vb.column, vb.nodeName, vb.severity)
+	violation.EndLine = vb.endLine
+	violation.EndColumn = vb.endColumn
+	violation.Tags = vb.tags
+	for _, related := range vb.related {
+		if related.File == "" {
+			related.File = vb.file
+		}
+		violation.Related = append(violation.Related, related)
+	}
+	violation.Fix = vb.fix
+	return violation
+}
@@ -116,3 +116,81 @@ func TestASTBuilderWithSimpleCode(t *testing.T) {
 		})
 	}
 }
+
+// TestASTBuilderControlFlowStatements tests loops, subroutines and control flow terminators
+func TestASTBuilderControlFlowStatements(t *testing.T) {
+	code := `OPENQASM 3.0;
+int[32] n;
+n = 2;
+for int i in [0:2:n] {
+    continue;
+}
+while (n > 0) {
+    n -= 1;
+    break;
+}
+def f(int[32] a, qubit b) -> int[32] {
+    return a;
+}
+end;`
+
+	result := parser.NewParser().ParseWithErrors(code)
+	if result.HasErrors() {
+		t.Fatalf("Unexpected parse errors: %v", result.Errors)
+	}
+
+	statements := result.Program.Statements
+	if len(statements) != 6 {
+		t.Fatalf("Expected 6 statements, got %d", len(statements))
+	}
+
+	if assign, ok := statements[1].(*parser.Assignment); !ok || assign.Operator != "=" {
+		t.Errorf("Expected assignment, got %T", statements[1])
+	}
+
+	forStmt, ok := statements[2].(*parser.ForStatement)
+	if !ok {
+		t.Fatalf("Expected for statement, got %T", statements[2])
+	}
+	if rangeExpr, ok := forStmt.Iterable.(*parser.RangeExpression); !ok || rangeExpr.Step == nil || rangeExpr.EndIndex == nil {
+		t.Errorf("Expected range iterable with step, got %#v", forStmt.Iterable)
+	}
+	if len(forStmt.Body) != 1 {
+		t.Errorf("Expected continue in for body, got %d statements", len(forStmt.Body))
+	} else if _, ok := forStmt.Body[0].(*parser.ContinueStatement); !ok {
+		t.Errorf("Expected continue statement, got %T", forStmt.Body[0])
+	}
+
+	whileStmt, ok := statements[3].(*parser.WhileStatement)
+	if !ok {
+		t.Fatalf("Expected while statement, got %T", statements[3])
+	}
+	if len(whileStmt.Body) != 2 {
+		t.Fatalf("Expected 2 statements in while body, got %d", len(whileStmt.Body))
+	}
+	if assign, ok := whileStmt.Body[0].(*parser.Assignment); !ok || assign.Operator != "-=" {
+		t.Errorf("Expected compound assignment, got %#v", whileStmt.Body[0])
+	}
+	if _, ok := whileStmt.Body[1].(*parser.BreakStatement); !ok {
+		t.Errorf("Expected break statement, got %T", whileStmt.Body[1])
+	}
+
+	def, ok := statements[4].(*parser.SubroutineDefinition)
+	if !ok {
+		t.Fatalf("Expected subroutine definition, got %T", statements[4])
+	}
+	if def.Name != "f" || def.ReturnType != "int[32]" || len(def.Parameters) != 2 {
+		t.Errorf("Unexpected subroutine signature: %#v", def)
+	} else if def.Parameters[0].Type != "int[32]" || def.Parameters[1].Name != "b" {
+		t.Errorf("Unexpected subroutine parameters: %#v", def.Parameters)
+	}
+	if len(def.Body) != 1 {
+		t.Errorf("Expected return in subroutine body, got %d statements", len(def.Body))
+	} else if ret, ok := def.Body[0].(*parser.ReturnStatement); !ok || ret.Value == nil {
+		t.Errorf("Expected return with value, got %#v", def.Body[0])
+	}
+
+	if _, ok := statements[5].(*parser.EndStatement); !ok {
+		t.Errorf("Expected end statement, got %T", statements[5])
+	}
+}
@@ -0,0 +1,1526 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// lintRule lints code and returns only the violations reported by ruleID
+func lintRule(t *testing.T, code, ruleID string) []*Violation {
+	t.Helper()
+
+	linter := NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	violations, err := linter.LintContent(code, "test.qasm")
+	if err != nil {
+		t.Fatalf("Failed to lint content: %v", err)
+	}
+
+	var filtered []*Violation
+	for _, v := range violations {
+		if v.Rule.ID == ruleID {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// ruleTestCase describes the expected violations of a rule for a code snippet
+type ruleTestCase struct {
+	name  string
+	code  string
+	lines []int // Expected violation lines, in order
+}
+
+// runRuleTests runs table-driven tests for a single rule
+func runRuleTests(t *testing.T, ruleID string, tests []ruleTestCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := lintRule(t, tt.code, ruleID)
+
+			if len(violations) != len(tt.lines) {
+				for _, v := range violations {
+					t.Logf("Violation: %s", v.String())
+				}
+				t.Fatalf("Expected %d %s violations, got %d", len(tt.lines), ruleID, len(violations))
+			}
+			for i, v := range violations {
+				if v.Line != tt.lines[i] {
+					t.Errorf("Expected violation %d on line %d, got %s", i, tt.lines[i], v.String())
+				}
+			}
+		})
+	}
+}
+
+func TestQubitUsedAfterMeasurement(t *testing.T) {
+	runRuleTests(t, "QAS013", []ruleTestCase{
+		{
+			name: "gate after measurement",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+h q;
+measure q -> c;
+x q;`,
+			lines: []int{6},
+		},
+		{
+			name: "reset before reuse",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+h q;
+measure q -> c;
+reset q;
+x q;`,
+		},
+		{
+			name: "register elements tracked separately",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+bit[2] c;
+h q[0];
+h q[1];
+c[0] = measure q[0];
+x q[1];
+x q[0];
+reset q[0];
+h q;`,
+			lines: []int{8},
+		},
+		{
+			name: "measurement inside if branch",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+bit[2] c;
+h q[0];
+measure q[0] -> c[0];
+if (c[0] == 1) {
+    measure q[1] -> c[1];
+}
+h q[1];`,
+			lines: []int{9},
+		},
+		{
+			name: "reset on only one branch",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+bit flag;
+h q;
+measure q -> c;
+if (flag) {
+    reset q;
+}
+h q;`,
+			lines: []int{10},
+		},
+		{
+			name: "active reset conditioned on the result",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+h q;
+measure q -> c;
+if (c == 1) {
+    x q;
+}
+h q;`,
+		},
+		{
+			name: "reset in a loop over the register",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+bit[2] c;
+c = measure q;
+for int i in [0:1] {
+    reset q[i];
+}
+h q;`,
+		},
+		{
+			name: "reset in a loop that may not run",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+bit[2] c;
+int n;
+c = measure q;
+for int i in [0:n] {
+    reset q[i];
+}
+h q;`,
+			lines: []int{9},
+		},
+		{
+			name: "measurement in a loop over the register",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+bit[2] c;
+for int i in [0:1] {
+    c[i] = measure q[i];
+}
+h q[0];`,
+			lines: []int{7},
+		},
+		{
+			name: "constant index",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+bit[2] c;
+const int i = 0;
+c[i] = measure q[i];
+h q[1];
+x q[i + 1 - 1];`,
+			lines: []int{7},
+		},
+	})
+}
+
+func TestQubitUsedAfterMeasurementNestedLoops(t *testing.T) {
+	// Every loop level must not multiply the work of the levels inside it
+	const depth = 40
+	code := "OPENQASM 3.0;\nqubit q;\nbit c;\n" +
+		strings.Repeat("while (true) {\n", depth) +
+		"h q;\nc = measure q;\n" +
+		strings.Repeat("}\n", depth)
+
+	violations := lintRule(t, code, "QAS013")
+	if len(violations) != 1 || violations[0].Line != depth+4 {
+		t.Fatalf("Expected one violation on line %d, got %v", depth+4, violations)
+	}
+}
+
+func TestDuplicateQubitOperand(t *testing.T) {
+	runRuleTests(t, "QAS014", []ruleTestCase{
+		{
+			name: "same indexed qubit",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+cx q[0], q[0];`,
+			lines: []int{3},
+		},
+		{
+			name: "distinct qubits",
+			code: `OPENQASM 3.0;
+qubit[3] q;
+qubit a;
+qubit b;
+cx q[0], q[1];
+ccx a, b, q[2];`,
+		},
+		{
+			name: "repeated single qubit",
+			code: `OPENQASM 3.0;
+qubit a;
+qubit b;
+ccx a, b, a;`,
+			lines: []int{4},
+		},
+		{
+			name: "register too large to expand",
+			code: `OPENQASM 3.0;
+qubit[1000000000] q;
+h q[0];
+cx q[0], q[1];
+cx q[1], q[1];`,
+			lines: []int{5},
+		},
+		{
+			name: "broadcast overlapping a single qubit",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+cx q, q[1];`,
+			lines: []int{3},
+		},
+		{
+			name: "broadcast over disjoint pairs",
+			code: `OPENQASM 3.0;
+qubit[3] q;
+cx q[0:1], q[1:2];`,
+		},
+		{
+			name: "constant folded index",
+			code: `OPENQASM 3.0;
+const int n = 1;
+qubit[3] q;
+cx q[n], q[2 - 1];`,
+			lines: []int{4},
+		},
+		{
+			name: "let alias",
+			code: `OPENQASM 3.0;
+qubit[4] q;
+let pair = q[2:3];
+cx pair[0], q[2];
+cx pair[1], q[0];`,
+			lines: []int{4},
+		},
+	})
+}
+
+func TestGateSignatureMismatch(t *testing.T) {
+	runRuleTests(t, "QAS015", []ruleTestCase{
+		{
+			name: "missing parameter",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+rx q;`,
+			lines: []int{4},
+		},
+		{
+			name: "missing qubit",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[2] q;
+cx q[0];
+cx q[0], q[1];`,
+			lines: []int{4},
+		},
+		{
+			name: "user gate definition",
+			code: `OPENQASM 3.0;
+gate mygate(theta) a, b {
+    U(theta, 0, 0) a;
+}
+qubit[2] q;
+mygate(0.5) q[0], q[1];
+mygate q[0], q[1];
+mygate(0.5) q[0];`,
+			lines: []int{7, 8},
+		},
+		{
+			name: "control modifiers",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+const int n = 2;
+qubit[3] q;
+ctrl @ x q[0], q[1];
+ctrl(n) @ x q[0], q[1], q[2];
+negctrl @ x q[0];`,
+			lines: []int{7},
+		},
+	})
+}
+
+func TestIdentifierRedeclaration(t *testing.T) {
+	runRuleTests(t, "QAS016", []ruleTestCase{
+		{
+			name: "redeclared qubit",
+			code: `OPENQASM 3.0;
+qubit q;
+qubit q;`,
+			lines: []int{3},
+		},
+		{
+			name: "loop variable shadows global",
+			code: `OPENQASM 3.0;
+qubit[4] q;
+int[32] n = 4;
+for int n in [0:3] {
+    x q[n];
+}`,
+			lines: []int{4},
+		},
+		{
+			name: "gate qubits do not shadow global registers",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+gate g(theta) q {
+    rx(theta) q;
+}
+g(0.1) q[0];`,
+		},
+		{
+			name: "gate parameter shadows global constant",
+			code: `OPENQASM 3.0;
+const float theta = 0.5;
+gate g(theta) a {
+    rx(theta) a;
+}`,
+			lines: []int{3},
+		},
+		{
+			name: "duplicate gate qubit",
+			code: `OPENQASM 3.0;
+gate g a, a {
+    x a;
+}`,
+			lines: []int{2},
+		},
+		{
+			name: "sibling scopes",
+			code: `OPENQASM 3.0;
+bit c;
+if (c) {
+    int[32] k = 1;
+} else {
+    int[32] k = 2;
+}`,
+		},
+	})
+}
+
+func TestIdentifierRedeclarationSeverityAndRelated(t *testing.T) {
+	violations := lintRule(t, `OPENQASM 3.0;
+qubit q;
+bit c;
+qubit q;
+if (c) {
+    bit c;
+}`, "QAS016")
+
+	if len(violations) != 2 {
+		t.Fatalf("Expected 2 QAS016 violations, got %d", len(violations))
+	}
+
+	redeclared, shadowed := violations[0], violations[1]
+	if redeclared.Severity != SeverityError {
+		t.Errorf("Expected redeclaration to be an error, got %s", redeclared.Severity)
+	}
+	if shadowed.Severity != SeverityWarning {
+		t.Errorf("Expected shadowing to be a warning, got %s", shadowed.Severity)
+	}
+
+	if len(redeclared.Related) != 1 || redeclared.Related[0].Line != 2 {
+		t.Errorf("Expected related location on line 2, got %+v", redeclared.Related)
+	}
+	if len(shadowed.Related) != 1 || shadowed.Related[0].Line != 3 || shadowed.Related[0].File != "test.qasm" {
+		t.Errorf("Expected related location test.qasm:3, got %+v", shadowed.Related)
+	}
+}
+
+func TestRecursiveGateDefinition(t *testing.T) {
+	runRuleTests(t, "QAS017", []ruleTestCase{
+		{
+			name: "direct recursion",
+			code: `OPENQASM 3.0;
+gate foo a {
+    foo a;
+}`,
+			lines: []int{2},
+		},
+		{
+			name: "mutual recursion reported at each gate",
+			code: `OPENQASM 3.0;
+gate ping a {
+    pong a;
+}
+gate pong a {
+    ctrl @ ping a;
+}`,
+			lines: []int{2, 5},
+		},
+		{
+			name: "separate cycles through a shared gate",
+			code: `OPENQASM 3.0;
+gate a q {
+    b q;
+    c q;
+}
+gate b q {
+    a q;
+}
+gate c q {
+    a q;
+}`,
+			lines: []int{2, 6, 9},
+		},
+		{
+			name: "gate calling into a cycle is not recursive",
+			code: `OPENQASM 3.0;
+gate a q {
+    b q;
+}
+gate b q {
+    a q;
+}
+gate outer q {
+    a q;
+}`,
+			lines: []int{2, 5},
+		},
+		{
+			name: "acyclic hierarchy",
+			code: `OPENQASM 3.0;
+gate inner a {
+    h a;
+}
+gate outer a {
+    inner a;
+    inner a;
+}`,
+		},
+	})
+}
+
+func TestRecursiveGateDefinitionPath(t *testing.T) {
+	violations := lintRule(t, `OPENQASM 3.0;
+gate a q {
+    b q;
+}
+gate b q {
+    c q;
+}
+gate c q {
+    a q;
+}`, "QAS017")
+
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 QAS017 violations, got %d", len(violations))
+	}
+	if expected := "Gate 'a' is defined recursively: a -> b -> c -> a."; violations[0].Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, violations[0].Message)
+	}
+	if expected := "Gate 'b' is defined recursively: b -> c -> a -> b."; violations[1].Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, violations[1].Message)
+	}
+	if len(violations[0].Related) != 3 || violations[0].Related[2].Line != 9 {
+		t.Errorf("Expected three call sites ending on line 9, got %+v", violations[0].Related)
+	}
+}
+
+func TestRecursiveGateDefinitionDenseGraph(t *testing.T) {
+	// Every gate calls every other gate, so the graph has millions of elementary cycles
+	const gates = 12
+	var code strings.Builder
+	code.WriteString("OPENQASM 3.0;\n")
+	for i := 0; i < gates; i++ {
+		fmt.Fprintf(&code, "gate g%d q {\n", i)
+		for j := 0; j < gates; j++ {
+			if j != i {
+				fmt.Fprintf(&code, "    g%d q;\n", j)
+			}
+		}
+		code.WriteString("}\n")
+	}
+
+	violations := lintRule(t, code.String(), "QAS017")
+	if len(violations) != gates {
+		t.Fatalf("Expected %d QAS017 violations, got %d", gates, len(violations))
+	}
+	for i, violation := range violations {
+		// The shortest cycle through each gate passes through one other gate
+		if prefix := fmt.Sprintf("Gate 'g%d' is defined recursively: g%d -> ", i, i); !strings.HasPrefix(violation.Message, prefix) {
+			t.Errorf("Expected message starting with %q, got %q", prefix, violation.Message)
+		}
+		if len(violation.Related) != 2 {
+			t.Errorf("Expected two call sites for g%d, got %d", i, len(violation.Related))
+		}
+	}
+}
+
+func TestClassicalReadBeforeAssignment(t *testing.T) {
+	runRuleTests(t, "QAS018", []ruleTestCase{
+		{
+			name: "read of uninitialized int",
+			code: `OPENQASM 3.0;
+int[32] x;
+if (x == 1) {
+    x = 0;
+}`,
+			lines: []int{3},
+		},
+		{
+			name: "initializer, assignment and measurement",
+			code: `OPENQASM 3.0;
+qubit q;
+int[32] a = 1;
+int[32] b;
+bit c;
+b = a + 1;
+c = measure q;
+if (c == b) {
+    a += 1;
+}`,
+		},
+		{
+			name: "assigned on one branch only",
+			code: `OPENQASM 3.0;
+bit flag;
+qubit q;
+flag = measure q;
+bool ok;
+if (flag) {
+    ok = true;
+}
+if (ok) {
+    x q;
+}`,
+			lines: []int{9},
+		},
+		{
+			name: "assigned on both branches",
+			code: `OPENQASM 3.0;
+bit flag;
+qubit q;
+flag = measure q;
+bool ok;
+if (flag) {
+    ok = true;
+} else {
+    ok = false;
+}
+if (ok) {
+    x q;
+}`,
+		},
+		{
+			name: "compound assignment and loop that may not run",
+			code: `OPENQASM 3.0;
+int[32] total;
+int[32] last;
+total += 1;
+for int i in [0:3] {
+    last = i;
+}
+total = last;`,
+			lines: []int{4, 8},
+		},
+		{
+			name: "index expressions are reads",
+			code: `OPENQASM 3.0;
+qubit[4] q;
+int[32] k;
+x q[k];`,
+			lines: []int{4},
+		},
+	})
+}
+
+func TestUnreachableCode(t *testing.T) {
+	runRuleTests(t, "QAS019", []ruleTestCase{
+		{
+			name: "constant false if",
+			code: `OPENQASM 3.0;
+qubit q;
+if (false) {
+    x q;
+}`,
+			lines: []int{3},
+		},
+		{
+			name: "folded constant condition",
+			code: `OPENQASM 3.0;
+const int debug = 0;
+qubit q;
+if (debug == 1 && true) {
+    x q;
+}`,
+			lines: []int{4},
+		},
+		{
+			name: "infinite loop followed by code",
+			code: `OPENQASM 3.0;
+qubit q;
+while (true) {
+    h q;
+}
+x q;`,
+			lines: []int{3, 6},
+		},
+		{
+			name: "infinite loop with break",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+while (true) {
+    c = measure q;
+    if (c) {
+        break;
+    }
+}
+x q;`,
+		},
+		{
+			name: "statements after break and return",
+			code: `OPENQASM 3.0;
+qubit q;
+for int i in [0:3] {
+    break;
+    x q;
+}
+def f() -> int {
+    return 1;
+    int[32] unused = 2;
+}`,
+			lines: []int{5, 9},
+		},
+		{
+			name: "runtime condition",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+c = measure q;
+if (c == 1) {
+    x q;
+}`,
+		},
+	})
+}
+
+func TestUnusedDeclaration(t *testing.T) {
+	runRuleTests(t, "QAS020", []ruleTestCase{
+		{
+			name: "unused classical variable",
+			code: `OPENQASM 3.0;
+qubit q;
+int[32] count = 0;
+U(0, 0, 0) q;`,
+			lines: []int{3},
+		},
+		{
+			name: "written variables are used",
+			code: `OPENQASM 3.0;
+qubit q;
+bit c;
+c = measure q;`,
+		},
+		{
+			name: "unused gate and subroutine",
+			code: `OPENQASM 3.0;
+qubit q;
+gate g a {
+    U(0, 0, 0) a;
+}
+def f() -> int {
+    return 1;
+}
+U(0, 0, 0) q;`,
+			lines: []int{3, 6},
+		},
+		{
+			name: "recursive calls do not count as uses",
+			code: `OPENQASM 3.0;
+qubit q;
+def f(int n) -> int {
+    return f(n - 1);
+}
+U(0, 0, 0) q;`,
+			lines: []int{3},
+		},
+		{
+			name: "mutually recursive gates do not count as uses",
+			code: `OPENQASM 3.0;
+qubit q;
+gate bar a {
+    baz a;
+}
+gate baz a {
+    bar a;
+}
+U(0, 0, 0) q;`,
+			lines: []int{3, 6},
+		},
+		{
+			name: "gates called from a called gate",
+			code: `OPENQASM 3.0;
+qubit q;
+gate inner a {
+    U(0, 0, 0) a;
+}
+gate outer a {
+    inner a;
+}
+outer q;`,
+		},
+		{
+			name: "called gate and subroutine",
+			code: `OPENQASM 3.0;
+qubit q;
+gate g a {
+    U(0, 0, 0) a;
+}
+def f() -> int {
+    return 1;
+}
+g q;
+f();`,
+		},
+		{
+			name: "unused standard include",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+U(0, 0, 0) q;`,
+			lines: []int{2},
+		},
+		{
+			name: "gates not defined in stdgates.inc",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[3] q;
+toffoli q[0], q[1], q[2];
+cnot q[0], q[1];`,
+			lines: []int{2},
+		},
+		{
+			name: "used standard include",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+h q;`,
+		},
+		{
+			name: "unresolvable include",
+			code: `OPENQASM 3.0;
+include "missing.inc";
+qubit q;
+U(0, 0, 0) q;`,
+		},
+	})
+}
+
+func TestUnusedDeclarationFix(t *testing.T) {
+	code := `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+int[32] count = 0;
+bit c = measure q;
+gate g a {
+    U(0, 0, 0) a;
+}
+U(0, 0, 0) q;
+`
+	violations := lintRule(t, code, "QAS020")
+	if len(violations) != 4 {
+		t.Fatalf("Expected 4 QAS020 violations, got %d", len(violations))
+	}
+	for _, v := range violations {
+		if v.Severity != SeverityInfo {
+			t.Errorf("Expected info severity, got %s", v.Severity)
+		}
+	}
+
+	fixed, applied := ApplyFixes(code, violations)
+	// The measurement initializer has side effects, so 'c' has no fix
+	if applied != 3 {
+		t.Errorf("Expected 3 fixes to be applied, got %d", applied)
+	}
+
+	expected := `OPENQASM 3.0;
+qubit q;
+bit c = measure q;
+U(0, 0, 0) q;
+`
+	if fixed != expected {
+		t.Errorf("Unexpected fixed content:\n%s", fixed)
+	}
+}
+
+func TestUnusedDeclarationInIncludeFile(t *testing.T) {
+	linter := NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	violations, err := linter.LintContent(`const float angle = 0.5;
+gate flip a {
+    U(pi, 0, pi) a;
+}
+def helper() {
+    int[32] scratch = 0;
+}`, "gates.inc")
+	if err != nil {
+		t.Fatalf("Failed to lint content: %v", err)
+	}
+
+	var unused []string
+	for _, v := range violations {
+		if v.Rule.ID == "QAS020" {
+			unused = append(unused, v.NodeName)
+		}
+	}
+	if !slices.Equal(unused, []string{"scratch"}) {
+		t.Errorf("Expected only the local 'scratch' to be unused, got %v", unused)
+	}
+}
+
+func TestDeprecatedQASM2Construct(t *testing.T) {
+	runRuleTests(t, "QAS021", []ruleTestCase{
+		{
+			name: "register declarations and legacy include",
+			code: `OPENQASM 3.0;
+include "qelib1.inc";
+qreg q[2];
+creg c[2];
+measure q -> c;`,
+			lines: []int{2, 3, 4},
+		},
+		{
+			name: "legacy built-in gates",
+			code: `OPENQASM 3.0;
+include "qelib1.inc";
+qubit[2] q;
+U(0, 0, 0) q[0];
+CX q[0], q[1];`,
+			lines: []int{2, 4, 5},
+		},
+		{
+			name: "U is not reported in OpenQASM 3 code",
+			code: `OPENQASM 3.0;
+qubit q;
+U(0, 0, 0) q;`,
+		},
+		{
+			name: "opaque gate",
+			code: `OPENQASM 3.0;
+qubit q;
+opaque g a;`,
+			lines: []int{3},
+		},
+		{
+			name: "whole register comparison",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[2] q;
+bit[2] c;
+bit b;
+c = measure q;
+b = measure q[0];
+if (c == 1) {
+    x q[0];
+}
+if (c[0] == 1) {
+    x q[0];
+}
+if (b == 1) {
+    x q[0];
+}`,
+			lines: []int{8},
+		},
+		{
+			name: "OpenQASM 2 files are not checked",
+			code: `OPENQASM 2.0;
+include "qelib1.inc";
+qreg q[2];
+CX q[0], q[1];`,
+		},
+	})
+}
+
+func TestDeprecatedQASM2ConstructFix(t *testing.T) {
+	code := `OPENQASM 3.0;
+include "qelib1.inc";
+qreg q[2];
+creg c[2];
+U(0, 0, 0) q[0];
+CX q[0], q[1];
+measure q -> c;
+if (c == 1) x q[0];
+`
+	violations := lintRule(t, code, "QAS021")
+
+	fixed, applied := ApplyFixes(code, violations)
+	if applied != 6 {
+		t.Errorf("Expected 6 fixes to be applied, got %d", applied)
+	}
+
+	expected := `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[2] q;
+bit[2] c;
+u3(0, 0, 0) q[0];
+cx q[0], q[1];
+measure q -> c;
+if (uint[2](c) == 1) x q[0];
+`
+	if fixed != expected {
+		t.Errorf("Unexpected fixed content:\n%s", fixed)
+	}
+}
+
+func TestSuspiciousAngle(t *testing.T) {
+	runRuleTests(t, "QAS022", []ruleTestCase{
+		{
+			name: "angles in degrees",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[2] q;
+rx(90) q[0];
+crz(-180) q[0], q[1];
+U(45, 0, 0) q[0];`,
+			lines: []int{4, 5, 6},
+		},
+		{
+			name: "folded constants",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+const float theta = 180;
+rz(theta) q;
+p(3 * pi) q;`,
+			lines: []int{5, 6},
+		},
+		{
+			name: "angles in radians",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+rx(pi/2) q;
+rz(-2 * pi) q;
+u3(tau, 0.5, 1) q;`,
+		},
+		{
+			name: "runtime and user-defined angles",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+gate spin(a) q {
+    rz(a) q;
+}
+input float theta;
+qubit q;
+rx(theta) q;
+spin(90) q;`,
+		},
+	})
+}
+
+func TestSuspiciousAngleMessageAndFix(t *testing.T) {
+	code := `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+rx(90) q;
+ry(-270) q;
+rz(10) q;
+`
+	violations := lintRule(t, code, "QAS022")
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 QAS022 violations, got %d", len(violations))
+	}
+	if expected := "Angle 90 of 'rx' looks like degrees; angles are in radians, did you mean 'pi/2'?"; violations[0].Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, violations[0].Message)
+	}
+	if violations[2].Fix != nil {
+		t.Errorf("Expected no fix for an angle that is not a whole degree multiple")
+	}
+
+	fixed, applied := ApplyFixes(code, violations)
+	if applied != 2 {
+		t.Errorf("Expected 2 fixes to be applied, got %d", applied)
+	}
+	expected := `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+rx(pi/2) q;
+ry(-3*pi/2) q;
+rz(10) q;
+`
+	if fixed != expected {
+		t.Errorf("Unexpected fixed content:\n%s", fixed)
+	}
+}
+
+func TestInvalidDuration(t *testing.T) {
+	runRuleTests(t, "QAS023", []ruleTestCase{
+		{
+			name: "zero, negative and unitless delays",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+delay[100] q;
+delay[-20ns] q[0];
+delay[0ns] q[1];
+box[0us] {
+    delay[10ns] q;
+}`,
+			lines: []int{3, 4, 5, 6},
+		},
+		{
+			name: "invalid duration arithmetic",
+			code: `OPENQASM 3.0;
+duration a = 100ns + 5;
+duration b = 100ns * 2ns;
+duration c = 10dt + 5ns;
+float[64] r = 2 / 100ns;`,
+			lines: []int{2, 3, 4, 5},
+		},
+		{
+			name: "units of duration variables",
+			code: `OPENQASM 3.0;
+qubit q;
+duration d = 100ns;
+delay[d + 10dt] q;
+duration e = 100ns;
+e = 10dt;
+delay[e + 10dt] q;`,
+			lines: []int{4},
+		},
+		{
+			name: "valid durations",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+stretch s;
+const duration d = 50ns;
+duration e = 2 * d + 1us;
+float[64] ratio = d / 10ns;
+delay[d] q;
+delay[s] q[0];
+box[1us] {
+    delay[e - d] q;
+}`,
+		},
+	})
+}
+
+func TestBoxDurationExceeded(t *testing.T) {
+	runRuleTests(t, "QAS024", []ruleTestCase{
+		{
+			name: "delays on one qubit exceed the box",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+const duration d = 50ns;
+box[100ns] {
+    delay[80ns] q[0];
+    delay[d] q[0];
+    delay[40ns] q[1];
+}
+box[100ns] {
+    delay[60ns] q;
+    delay[60ns] q[1];
+}`,
+			lines: []int{4, 9},
+		},
+		{
+			name: "delays fit within the box",
+			code: `OPENQASM 3.0;
+qubit[2] q;
+stretch s;
+box[100ns] {
+    delay[80ns] q[0];
+    delay[80ns] q[1];
+}
+box[100ns] {
+    delay[s] q;
+    delay[100ns] q[0];
+}
+box {
+    delay[1ms] q;
+}`,
+		},
+	})
+}
+
+func TestStretchOutsideTiming(t *testing.T) {
+	runRuleTests(t, "QAS025", []ruleTestCase{
+		{
+			name: "stretch used as a value",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+stretch s;
+rx(s) q;
+float[64] f = s;`,
+			lines: []int{5, 6},
+		},
+		{
+			name: "stretch in timing contexts",
+			code: `OPENQASM 3.0;
+qubit q;
+stretch s;
+duration d = 2 * s + 10ns;
+stretch t = s;
+d = s;
+delay[s] q;
+box[s] {
+    delay[d] q;
+}`,
+		},
+	})
+}
+
+func TestUnsupportedOnTarget(t *testing.T) {
+	target := &Target{
+		Name:        "line",
+		Qubits:      3,
+		NativeGates: []string{"rz", "sx", "x", "cx"},
+		CouplingMap: [][2]int{{0, 1}, {1, 2}},
+	}
+
+	tests := []ruleTestCase{
+		{
+			name: "non-native gates, uncoupled and missing qubits",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+bit c;
+h $0;
+cx $0, $2;
+cx $1, $0;
+x $3;
+ctrl @ x $1, $2;
+c = measure $4;`,
+			lines: []int{4, 5, 7, 8, 9},
+		},
+		{
+			name: "too many declared qubits",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+const int n = 2;
+qubit[n] a;
+qubit b;
+qubit[2] d;
+x a[0];`,
+			lines: []int{6},
+		},
+		{
+			name: "user-defined gates built from native gates",
+			code: `OPENQASM 3.0;
+gate bell a, b {
+    rz(pi/2) a;
+    sx a;
+    cx a, b;
+}
+bell $1, $2;
+rz(pi) $0;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter := NewLinter("")
+			linter.SetTarget(target)
+			if err := linter.LoadRules(); err != nil {
+				t.Fatalf("Failed to load rules: %v", err)
+			}
+			violations, err := linter.LintContent(tt.code, "test.qasm")
+			if err != nil {
+				t.Fatalf("Failed to lint content: %v", err)
+			}
+
+			var lines []int
+			for _, v := range violations {
+				if v.Rule.ID == "QAS026" {
+					lines = append(lines, v.Line)
+					t.Logf("Violation: %s", v.String())
+				}
+			}
+			if len(lines) != len(tt.lines) {
+				t.Fatalf("Expected QAS026 violations on lines %v, got %v", tt.lines, lines)
+			}
+			for i := range lines {
+				if lines[i] != tt.lines[i] {
+					t.Errorf("Expected QAS026 violations on lines %v, got %v", tt.lines, lines)
+					break
+				}
+			}
+		})
+	}
+
+	// Without a target the rule stays silent
+	runRuleTests(t, "QAS026", []ruleTestCase{
+		{
+			name: "no target configured",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+h $0;
+cx $0, $7;`,
+		},
+	})
+}
+
+func TestUndefinedIdentifierPhysicalQubits(t *testing.T) {
+	runRuleTests(t, "QAS002", []ruleTestCase{
+		{
+			name: "physical qubits need no declaration",
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+bit c;
+h $0;
+cx $0, $1;
+c = measure $1;`,
+		},
+	})
+}
+
+func TestResourceLimitExceeded(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits map[string]int
+		code   string
+		lines  []int
+	}{
+		{
+			name:   "qubit count",
+			limits: map[string]int{"qubits": 4},
+			code: `OPENQASM 3.0;
+const int n = 3;
+qubit[n] a;
+qubit b;
+qubit[2] c;`,
+			lines: []int{5},
+		},
+		{
+			name:   "two-qubit gate count",
+			limits: map[string]int{"two_qubit_gates": 2},
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[3] q;
+cx q[0], q[1];
+h q[2];
+for int i in [0:1] {
+    cz q[1], q[2];
+}
+swap q[0], q[2];`,
+			lines: []int{9},
+		},
+		{
+			name:   "circuit depth",
+			limits: map[string]int{"depth": 3},
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[2] q;
+h q[0];
+h q[1];
+x q[1];
+cx q[0], q[1];
+h q;
+reset q[0];`,
+			lines: []int{8},
+		},
+		{
+			name:   "loop nesting",
+			limits: map[string]int{"loop_nesting": 1},
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+for int i in [0:2] {
+    if (i == 1) {
+        while (false) {
+            for int j in [0:1] {
+                h q;
+            }
+        }
+    }
+}
+def f() {
+    for int k in [0:1] {
+        h q;
+    }
+}`,
+			lines: []int{6},
+		},
+		{
+			name:   "within limits",
+			limits: map[string]int{"qubits": 2, "two_qubit_gates": 1, "depth": 2, "loop_nesting": 1},
+			code: `OPENQASM 3.0;
+include "stdgates.inc";
+qubit[2] q;
+h q[0];
+h q[1];
+for int i in [0:3] {
+    cx q[0], q[1];
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linter := NewLinter("")
+			if err := linter.LoadRules(); err != nil {
+				t.Fatalf("Failed to load rules: %v", err)
+			}
+			for _, rule := range linter.GetRules() {
+				if rule.ID != "QAS027" {
+					continue
+				}
+				rule.Check = nil
+				for target, max := range tt.limits {
+					rule.Check = append(rule.Check, Check{Type: "count", Target: target, Max: max})
+				}
+			}
+
+			violations, err := linter.LintContent(tt.code, "test.qasm")
+			if err != nil {
+				t.Fatalf("Failed to lint content: %v", err)
+			}
+
+			var lines []int
+			for _, v := range violations {
+				if v.Rule.ID == "QAS027" {
+					lines = append(lines, v.Line)
+					t.Logf("Violation: %s", v.String())
+				}
+			}
+			if !slices.Equal(lines, tt.lines) {
+				t.Errorf("Expected QAS027 violations on lines %v, got %v", tt.lines, lines)
+			}
+		})
+	}
+}
+
+func TestIllegalBreakContinue(t *testing.T) {
+	runRuleTests(t, "QAS009", []ruleTestCase{
+		{
+			name: "outside loops and in subroutine bodies",
+			code: `OPENQASM 3.0;
+bit c;
+break;
+if (c) {
+    continue;
+}
+for int i in [0:3] {
+    def f() {
+        break;
+    }
+}`,
+			lines: []int{3, 5, 9},
+		},
+		{
+			name: "inside loops",
+			code: `OPENQASM 3.0;
+bit c;
+for int i in [0:3] {
+    if (c) { break; } else { continue; }
+}
+while (c) {
+    // a comment mentioning break
+    box { continue; }
+}`,
+		},
+	})
+}
+
+func TestIllegalBreakContinueRange(t *testing.T) {
+	violations := lintRule(t, "OPENQASM 3.0;\n  continue;", "QAS009")
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 QAS009 violation, got %d", len(violations))
+	}
+	v := violations[0]
+	if v.Line != 2 || v.Column != 3 || v.EndLine != 2 || v.EndColumn != 11 {
+		t.Errorf("Expected range 2:3-2:11, got %d:%d-%d:%d", v.Line, v.Column, v.EndLine, v.EndColumn)
+	}
+}
+
+func TestMeasurementRegisterMismatch(t *testing.T) {
+	runRuleTests(t, "QAS030", []ruleTestCase{
+		{
+			name: "whole registers of different sizes",
+			code: `OPENQASM 3.0;
+qubit[4] q;
+bit[2] c;
+measure q -> c;
+c = measure q;
+bit[3] d = measure q;
+creg e[2];
+measure q -> e;`,
+			lines: []int{4, 5, 6, 8},
+		},
+		{
+			name: "matching registers, elements, sets and slices",
+			code: `OPENQASM 3.0;
+const int n = 3;
+qubit[4] q;
+qubit[n] r;
+bit[4] c;
+bit[n] d;
+bit[2] e;
+bit b;
+measure q -> c;
+d = measure r;
+bit[4] all = measure q;
+b = measure q[0];
+c[1] = measure q[1];
+e = measure q[{0, 3}];
+e = measure q[2:3];
+e = measure q[-2:];
+d = measure q[1:];
+e = measure q[0:2:3];
+c[0:1] = measure r[1:2];
+measure $0 -> b;`,
+		},
+		{
+			name: "mismatched sets and slices",
+			code: `OPENQASM 3.0;
+const int n = 3;
+qubit[4] q;
+bit[2] e;
+bit[n] d;
+e = measure q[{0, 1, 2}];
+e = measure q[0:2];
+d = measure q[:];
+measure q[0] -> e;
+d[0:1] = measure q[0:n-1];`,
+			lines: []int{6, 7, 8, 9, 10},
+		},
+		{
+			name: "measuring classical values and storing in non-bit types",
+			code: `OPENQASM 3.0;
+qubit q;
+bit b;
+bit[2] c;
+int[8] i;
+float f;
+measure b -> c[0];
+measure q -> i;
+f = measure q;
+i[0] = measure q;`,
+			lines: []int{7, 8, 9},
+		},
+		{
+			name: "unknown widths and undeclared operands",
+			code: `OPENQASM 3.0;
+input int n;
+qubit[4] q;
+bit[2] c;
+for int i in [0:1] {
+    measure q[i:i+2] -> c;
+}
+measure p -> c;
+measure q -> missing;`,
+		},
+		{
+			name: "nested blocks and shadowing",
+			code: `OPENQASM 3.0;
+qubit[4] q;
+bit[2] c;
+bit flag;
+if (flag) {
+    bit[4] c;
+    measure q -> c;
+} else {
+    measure q -> c;
+}
+for int i in [0:1] {
+    while (flag) {
+        measure q -> c;
+    }
+}
+def f(qubit[2] q, bit[2] c) {
+    measure q -> c;
+}`,
+			lines: []int{9, 13},
+		},
+	})
+}
+
+func TestMeasurementRegisterMismatchMessages(t *testing.T) {
+	code := `OPENQASM 3.0;
+qubit[4] q;
+bit[2] c;
+int[8] i;
+measure q -> c;
+measure c -> c;
+measure q[0] -> i;
+`
+	violations := lintRule(t, code, "QAS030")
+	if len(violations) != 3 {
+		t.Fatalf("Expected 3 QAS030 violations, got %d", len(violations))
+	}
+	expected := []string{
+		"Measuring 4 qubits of 'q' into 2 bits of 'c'; the widths must match.",
+		"Cannot measure 'c' of type bit[2]; only qubits can be measured.",
+		"Cannot store a measurement in 'i' of type int[8]; measurement results are bits.",
+	}
+	for i, message := range expected {
+		if violations[i].Message != message {
+			t.Errorf("Expected message %q, got %q", message, violations[i].Message)
+		}
+	}
+}
@@ -49,6 +49,11 @@ func VisitAllNodes(node parser.Node, visitor func(parser.Node)) {
 			VisitAllNodes(n.Initializer, visitor)
 		}
 
+	case *parser.AliasDeclaration:
+		if n.Value != nil {
+			VisitAllNodes(n.Value, visitor)
+		}
+
 	case *parser.GateCall:
 		if n == nil {
 			return
@@ -74,6 +79,18 @@ func VisitAllNodes(node parser.Node, visitor func(parser.Node)) {
 			VisitAllNodes(n.Target, visitor)
 		}
 
+	case *parser.ExpressionStatement:
+		VisitAllNodes(n.Expression, visitor)
+
+	case *parser.Assignment:
+		VisitAllNodes(n.Target, visitor)
+		VisitAllNodes(n.Value, visitor)
+
+	case *parser.Reset:
+		if n.Qubit != nil {
+			VisitAllNodes(n.Qubit, visitor)
+		}
+
 	case *parser.GateDefinition:
 		for _, param := range n.Parameters {
 			VisitAllNodes(&param, visitor)
@@ -85,6 +102,17 @@ func VisitAllNodes(node parser.Node, visitor func(parser.Node)) {
 			VisitAllNodes(stmt, visitor)
 		}
 
+	case *parser.SubroutineDefinition:
+		for _, param := range n.Parameters {
+			VisitAllNodes(&param, visitor)
+		}
+		for _, stmt := range n.Body {
+			VisitAllNodes(stmt, visitor)
+		}
+
+	case *parser.ReturnStatement:
+		VisitAllNodes(n.Value, visitor)
+
 	case *parser.IfStatement:
 		VisitAllNodes(n.Condition, visitor)
 		for _, stmt := range n.ThenBody {
@@ -106,13 +134,36 @@ func VisitAllNodes(node parser.Node, visitor func(parser.Node)) {
 			VisitAllNodes(stmt, visitor)
 		}
 
+	case *parser.DelayStatement:
+		VisitAllNodes(n.Duration, visitor)
+		for _, qubit := range n.Qubits {
+			VisitAllNodes(qubit, visitor)
+		}
+
+	case *parser.BoxStatement:
+		VisitAllNodes(n.Duration, visitor)
+		for _, stmt := range n.Body {
+			VisitAllNodes(stmt, visitor)
+		}
+
 	case *parser.IndexedIdentifier:
 		VisitAllNodes(n.Index, visitor)
 
 	case *parser.RangedIdentifier:
 		VisitAllNodes(n.Start, visitor)
+		VisitAllNodes(n.Step, visitor)
+		VisitAllNodes(n.EndIndex, visitor)
+
+	case *parser.RangeExpression:
+		VisitAllNodes(n.Start, visitor)
+		VisitAllNodes(n.Step, visitor)
 		VisitAllNodes(n.EndIndex, visitor)
 
+	case *parser.SetExpression:
+		for _, element := range n.Elements {
+			VisitAllNodes(element, visitor)
+		}
+
 	case *parser.BinaryExpression:
 		VisitAllNodes(n.Left, visitor)
 		VisitAllNodes(n.Right, visitor)
@@ -162,6 +213,8 @@ func FindDeclarations(program *parser.Program) *Declarations {
 		Quantum:   make([]*parser.QuantumDeclaration, 0),
 		Classical: make([]*parser.ClassicalDeclaration, 0),
 		Gates:     make([]*parser.GateDefinition, 0),
+		Aliases:     make([]*parser.AliasDeclaration, 0),
+		Subroutines: make([]*parser.SubroutineDefinition, 0),
 	}
 
 	VisitAllNodes(program, func(node parser.Node) {
@@ -178,6 +231,14 @@ func FindDeclarations(program *parser.Program) *Declarations {
 			if n != nil {
 				declarations.Gates = append(declarations.Gates, n)
 			}
+		case *parser.AliasDeclaration:
+			if n != nil {
+				declarations.Aliases = append(declarations.Aliases, n)
+			}
+		case *parser.SubroutineDefinition:
+			if n != nil {
+				declarations.Subroutines = append(declarations.Subroutines, n)
+			}
 		}
 	})
 
@@ -189,6 +250,8 @@ type Declarations struct {
 	Quantum   []*parser.QuantumDeclaration
 	Classical []*parser.ClassicalDeclaration
 	Gates     []*parser.GateDefinition
+	Aliases     []*parser.AliasDeclaration
+	Subroutines []*parser.SubroutineDefinition
 }
 
 // GetUsages finds all usages of a given identifier in the program
@@ -350,6 +413,9 @@ func GetIdentifierUsages(program *parser.Program) map[string][]parser.Node {
 		case *parser.GateCall:
 			// Record gate name usage
 			usageMap[n.Name] = append(usageMap[n.Name], node)
+		case *parser.FunctionCall:
+			// Record subroutine name usage
+			usageMap[n.Name] = append(usageMap[n.Name], node)
 		}
 	})
 
@@ -368,6 +434,12 @@ func GetDeclaredIdentifiers(program *parser.Program) map[string]parser.Node {
 			declared[n.Identifier] = node
 		case *parser.GateDefinition:
 			declared[n.Name] = node
+		case *parser.AliasDeclaration:
+			declared[n.Identifier] = node
+		case *parser.ForStatement:
+			declared[n.Variable] = node
+		case *parser.SubroutineDefinition:
+			declared[n.Name] = node
 		}
 	})
 
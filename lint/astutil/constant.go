@@ -0,0 +1,300 @@
+package astutil
+
+import (
+	"math"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// IntegerConstants collects the values of integer const declarations in declaration order
+func IntegerConstants(program *parser.Program) map[string]int64 {
+	constants := make(map[string]int64)
+
+	VisitAllNodes(program, func(node parser.Node) {
+		decl, ok := node.(*parser.ClassicalDeclaration)
+		if !ok || !decl.Const || decl.Initializer == nil {
+			return
+		}
+		if value, ok := EvaluateInteger(decl.Initializer, constants); ok {
+			constants[decl.Identifier] = value
+		}
+	})
+
+	return constants
+}
+
+// EvaluateInteger folds an integer constant expression. Identifiers are looked up
+// in constants; ok is false if the expression is not a compile-time integer.
+func EvaluateInteger(expr parser.Expression, constants map[string]int64) (int64, bool) {
+	switch e := expr.(type) {
+	case *parser.IntegerLiteral:
+		return e.Value, true
+
+	case *parser.Identifier:
+		value, ok := constants[e.Name]
+		return value, ok
+
+	case *parser.ParenthesizedExpression:
+		return EvaluateInteger(e.Expression, constants)
+
+	case *parser.UnaryExpression:
+		operand, ok := EvaluateInteger(e.Operand, constants)
+		if !ok {
+			return 0, false
+		}
+		switch e.Operator {
+		case "-":
+			return -operand, true
+		case "+":
+			return operand, true
+		case "~":
+			return ^operand, true
+		}
+
+	case *parser.BinaryExpression:
+		left, ok := EvaluateInteger(e.Left, constants)
+		if !ok {
+			return 0, false
+		}
+		right, ok := EvaluateInteger(e.Right, constants)
+		if !ok {
+			return 0, false
+		}
+		return evaluateIntegerOperator(e.Operator, left, right)
+	}
+
+	return 0, false
+}
+
+// evaluateIntegerOperator applies a binary integer operator
+func evaluateIntegerOperator(operator string, left, right int64) (int64, bool) {
+	switch operator {
+	case "+":
+		return left + right, true
+	case "-":
+		return left - right, true
+	case "*":
+		return left * right, true
+	case "/":
+		if right == 0 {
+			return 0, false
+		}
+		return left / right, true
+	case "%":
+		if right == 0 {
+			return 0, false
+		}
+		return left % right, true
+	case "**":
+		if right < 0 || right > 63 {
+			return 0, false
+		}
+		result := int64(1)
+		for i := int64(0); i < right; i++ {
+			result *= left
+		}
+		return result, true
+	case "<<":
+		if right < 0 {
+			return 0, false
+		}
+		return left << uint64(right), true
+	case ">>":
+		if right < 0 {
+			return 0, false
+		}
+		return left >> uint64(right), true
+	case "&":
+		return left & right, true
+	case "|":
+		return left | right, true
+	case "^":
+		return left ^ right, true
+	}
+
+	return 0, false
+}
+
+// BooleanConstants collects the values of boolean const declarations in declaration order
+func BooleanConstants(program *parser.Program, integers map[string]int64) map[string]bool {
+	constants := make(map[string]bool)
+
+	VisitAllNodes(program, func(node parser.Node) {
+		decl, ok := node.(*parser.ClassicalDeclaration)
+		if !ok || !decl.Const || decl.Initializer == nil || decl.Type != "bool" {
+			return
+		}
+		if value, ok := EvaluateBoolean(decl.Initializer, integers, constants); ok {
+			constants[decl.Identifier] = value
+		}
+	})
+
+	return constants
+}
+
+// EvaluateBoolean folds a boolean constant expression such as a condition. Integer
+// operands are folded with EvaluateInteger; a nonzero integer is true. ok is false
+// if the expression is not a compile-time constant.
+func EvaluateBoolean(expr parser.Expression, integers map[string]int64, booleans map[string]bool) (bool, bool) {
+	switch e := expr.(type) {
+	case *parser.BooleanLiteral:
+		return e.Value, true
+
+	case *parser.Identifier:
+		if value, ok := booleans[e.Name]; ok {
+			return value, true
+		}
+
+	case *parser.ParenthesizedExpression:
+		return EvaluateBoolean(e.Expression, integers, booleans)
+
+	case *parser.UnaryExpression:
+		if e.Operator == "!" {
+			operand, ok := EvaluateBoolean(e.Operand, integers, booleans)
+			return !operand, ok
+		}
+
+	case *parser.BinaryExpression:
+		switch e.Operator {
+		case "&&", "||":
+			left, leftOK := EvaluateBoolean(e.Left, integers, booleans)
+			right, rightOK := EvaluateBoolean(e.Right, integers, booleans)
+			// A known operand can decide the result on its own
+			short := e.Operator == "||"
+			if (leftOK && left == short) || (rightOK && right == short) {
+				return short, true
+			}
+			if leftOK && rightOK {
+				return !short, true
+			}
+			return false, false
+
+		case "==", "!=", "<", "<=", ">", ">=":
+			if left, ok := EvaluateInteger(e.Left, integers); ok {
+				if right, ok := EvaluateInteger(e.Right, integers); ok {
+					return compareIntegers(e.Operator, left, right), true
+				}
+			}
+			if e.Operator == "==" || e.Operator == "!=" {
+				left, leftOK := EvaluateBoolean(e.Left, integers, booleans)
+				right, rightOK := EvaluateBoolean(e.Right, integers, booleans)
+				if leftOK && rightOK {
+					return (left == right) == (e.Operator == "=="), true
+				}
+			}
+			return false, false
+		}
+	}
+
+	if value, ok := EvaluateInteger(expr, integers); ok {
+		return value != 0, true
+	}
+	return false, false
+}
+
+// compareIntegers applies an integer comparison operator
+func compareIntegers(operator string, left, right int64) bool {
+	switch operator {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	default:
+		return left >= right
+	}
+}
+
+// floatBuiltins holds the built-in mathematical constants of OpenQASM 3
+var floatBuiltins = map[string]float64{
+	"pi":    math.Pi,
+	"π":     math.Pi,
+	"tau":   2 * math.Pi,
+	"τ":     2 * math.Pi,
+	"euler": math.E,
+	"ℇ":     math.E,
+}
+
+// FloatConstants collects the values of numeric const declarations in declaration order
+func FloatConstants(program *parser.Program) map[string]float64 {
+	constants := make(map[string]float64)
+
+	VisitAllNodes(program, func(node parser.Node) {
+		decl, ok := node.(*parser.ClassicalDeclaration)
+		if !ok || !decl.Const || decl.Initializer == nil {
+			return
+		}
+		if value, ok := EvaluateFloat(decl.Initializer, constants); ok {
+			constants[decl.Identifier] = value
+		}
+	})
+
+	return constants
+}
+
+// EvaluateFloat folds a real-valued constant expression such as a gate angle.
+// Identifiers are looked up in constants and the built-in constants pi, tau and
+// euler; ok is false if the expression is not a compile-time number.
+func EvaluateFloat(expr parser.Expression, constants map[string]float64) (float64, bool) {
+	switch e := expr.(type) {
+	case *parser.IntegerLiteral:
+		return float64(e.Value), true
+
+	case *parser.FloatLiteral:
+		return e.Value, true
+
+	case *parser.Identifier:
+		if value, ok := constants[e.Name]; ok {
+			return value, true
+		}
+		value, ok := floatBuiltins[e.Name]
+		return value, ok
+
+	case *parser.ParenthesizedExpression:
+		return EvaluateFloat(e.Expression, constants)
+
+	case *parser.UnaryExpression:
+		operand, ok := EvaluateFloat(e.Operand, constants)
+		if !ok {
+			return 0, false
+		}
+		switch e.Operator {
+		case "-":
+			return -operand, true
+		case "+":
+			return operand, true
+		}
+
+	case *parser.BinaryExpression:
+		left, ok := EvaluateFloat(e.Left, constants)
+		if !ok {
+			return 0, false
+		}
+		right, ok := EvaluateFloat(e.Right, constants)
+		if !ok {
+			return 0, false
+		}
+		switch e.Operator {
+		case "+":
+			return left + right, true
+		case "-":
+			return left - right, true
+		case "*":
+			return left * right, true
+		case "/":
+			if right == 0 {
+				return 0, false
+			}
+			return left / right, true
+		case "**":
+			return math.Pow(left, right), true
+		}
+	}
+
+	return 0, false
+}
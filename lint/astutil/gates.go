@@ -0,0 +1,87 @@
+package astutil
+
+import (
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// GateSignature describes the number of classical parameters and qubits a gate takes
+type GateSignature struct {
+	Parameters int
+	Qubits     int
+}
+
+// StandardGates lists the signatures of the built-in gates (U, gphase) and the
+// gates defined in stdgates.inc, including the legacy OpenQASM 2 names it keeps
+var StandardGates = map[string]GateSignature{
+	// Built-in gates
+	"U":      {Parameters: 3, Qubits: 1},
+	"gphase": {Parameters: 1, Qubits: 0},
+
+	// Single-qubit gates
+	"id":    {Parameters: 0, Qubits: 1},
+	"x":     {Parameters: 0, Qubits: 1},
+	"y":     {Parameters: 0, Qubits: 1},
+	"z":     {Parameters: 0, Qubits: 1},
+	"h":     {Parameters: 0, Qubits: 1},
+	"s":     {Parameters: 0, Qubits: 1},
+	"sdg":   {Parameters: 0, Qubits: 1},
+	"t":     {Parameters: 0, Qubits: 1},
+	"tdg":   {Parameters: 0, Qubits: 1},
+	"sx":    {Parameters: 0, Qubits: 1},
+	"p":     {Parameters: 1, Qubits: 1},
+	"phase": {Parameters: 1, Qubits: 1},
+	"rx":    {Parameters: 1, Qubits: 1},
+	"ry":    {Parameters: 1, Qubits: 1},
+	"rz":    {Parameters: 1, Qubits: 1},
+	"u1":    {Parameters: 1, Qubits: 1},
+	"u2":    {Parameters: 2, Qubits: 1},
+	"u3":    {Parameters: 3, Qubits: 1},
+
+	// Two-qubit gates
+	"CX":     {Parameters: 0, Qubits: 2},
+	"cx":     {Parameters: 0, Qubits: 2},
+	"cy":     {Parameters: 0, Qubits: 2},
+	"cz":     {Parameters: 0, Qubits: 2},
+	"ch":     {Parameters: 0, Qubits: 2},
+	"swap":   {Parameters: 0, Qubits: 2},
+	"cp":     {Parameters: 1, Qubits: 2},
+	"cphase": {Parameters: 1, Qubits: 2},
+	"crx":    {Parameters: 1, Qubits: 2},
+	"cry":    {Parameters: 1, Qubits: 2},
+	"crz":    {Parameters: 1, Qubits: 2},
+	"cu":     {Parameters: 4, Qubits: 2},
+
+	// Three-qubit gates
+	"ccx":   {Parameters: 0, Qubits: 3},
+	"cswap": {Parameters: 0, Qubits: 3},
+}
+
+// StandardIncludes lists the well-known include files, which provide the
+// standard gates without being read from disk
+var StandardIncludes = map[string]bool{
+	"stdgates.inc": true,
+	"qelib1.inc":   true,
+}
+
+// ResolveGateSignature returns the signature of a gate, preferring user definitions
+// over the standard gates
+func ResolveGateSignature(name string, definitions map[string]*parser.GateDefinition) (GateSignature, bool) {
+	if gateDef, exists := definitions[name]; exists {
+		return GateSignature{
+			Parameters: len(gateDef.Parameters),
+			Qubits:     len(gateDef.Qubits),
+		}, true
+	}
+
+	signature, exists := StandardGates[name]
+	return signature, exists
+}
+
+// GateDefinitionsByName indexes the gate definitions of a program by name
+func GateDefinitionsByName(program *parser.Program) map[string]*parser.GateDefinition {
+	definitions := make(map[string]*parser.GateDefinition)
+	for _, gateDef := range FindNodesByType(program, (*parser.GateDefinition)(nil)) {
+		definitions[gateDef.Name] = gateDef
+	}
+	return definitions
+}
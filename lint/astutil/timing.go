@@ -0,0 +1,141 @@
+package astutil
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// Duration is a folded duration. SI durations are normalized to nanoseconds;
+// durations in dt are kept in samples because their length depends on the backend.
+type Duration struct {
+	Value float64
+	Unit  string // "ns" or "dt"
+}
+
+// String formats the duration with its unit
+func (d Duration) String() string {
+	return strconv.FormatFloat(d.Value, 'g', 6, 64) + d.Unit
+}
+
+// durationScales converts timing literal units to nanoseconds
+var durationScales = map[string]float64{
+	"ns": 1,
+	"us": 1e3,
+	"µs": 1e3,
+	"ms": 1e6,
+	"s":  1e9,
+}
+
+// TimingUnit returns the normalized unit of a timing literal ("ns" or "dt")
+func TimingUnit(timing *parser.TimingExpression) string {
+	if strings.TrimSpace(timing.Unit) == "dt" {
+		return "dt"
+	}
+	return "ns"
+}
+
+// IsDurationType reports whether a declared type holds a duration
+func IsDurationType(declType string) bool {
+	return declType == "duration" || declType == "stretch"
+}
+
+// DurationConstants collects the values of const duration declarations in declaration order
+func DurationConstants(program *parser.Program, numbers map[string]float64) map[string]Duration {
+	constants := make(map[string]Duration)
+
+	VisitAllNodes(program, func(node parser.Node) {
+		decl, ok := node.(*parser.ClassicalDeclaration)
+		if !ok || !decl.Const || decl.Initializer == nil || decl.Type != "duration" {
+			return
+		}
+		if value, ok := EvaluateDuration(decl.Initializer, constants, numbers); ok {
+			constants[decl.Identifier] = value
+		}
+	})
+
+	return constants
+}
+
+// EvaluateDuration folds a duration constant expression. Duration identifiers are
+// looked up in durations and scalar factors are folded with EvaluateFloat using
+// numbers; ok is false if the expression is not a compile-time duration or mixes
+// dt with SI units.
+func EvaluateDuration(expr parser.Expression, durations map[string]Duration, numbers map[string]float64) (Duration, bool) {
+	switch e := expr.(type) {
+	case *parser.TimingExpression:
+		value, ok := EvaluateFloat(e.Value, nil)
+		if !ok {
+			return Duration{}, false
+		}
+		unit := TimingUnit(e)
+		if unit == "ns" {
+			scale, known := durationScales[strings.TrimSpace(e.Unit)]
+			if !known {
+				return Duration{}, false
+			}
+			value *= scale
+		}
+		return Duration{Value: value, Unit: unit}, true
+
+	case *parser.Identifier:
+		value, ok := durations[e.Name]
+		return value, ok
+
+	case *parser.ParenthesizedExpression:
+		return EvaluateDuration(e.Expression, durations, numbers)
+
+	case *parser.UnaryExpression:
+		operand, ok := EvaluateDuration(e.Operand, durations, numbers)
+		if !ok {
+			return Duration{}, false
+		}
+		switch e.Operator {
+		case "-":
+			return Duration{Value: -operand.Value, Unit: operand.Unit}, true
+		case "+":
+			return operand, true
+		}
+
+	case *parser.BinaryExpression:
+		switch e.Operator {
+		case "+", "-":
+			left, ok := EvaluateDuration(e.Left, durations, numbers)
+			if !ok {
+				return Duration{}, false
+			}
+			right, ok := EvaluateDuration(e.Right, durations, numbers)
+			if !ok || left.Unit != right.Unit {
+				return Duration{}, false
+			}
+			if e.Operator == "-" {
+				right.Value = -right.Value
+			}
+			return Duration{Value: left.Value + right.Value, Unit: left.Unit}, true
+
+		case "*":
+			if left, ok := EvaluateDuration(e.Left, durations, numbers); ok {
+				if factor, ok := EvaluateFloat(e.Right, numbers); ok {
+					return Duration{Value: left.Value * factor, Unit: left.Unit}, true
+				}
+			}
+			if right, ok := EvaluateDuration(e.Right, durations, numbers); ok {
+				if factor, ok := EvaluateFloat(e.Left, numbers); ok {
+					return Duration{Value: right.Value * factor, Unit: right.Unit}, true
+				}
+			}
+
+		case "/":
+			left, ok := EvaluateDuration(e.Left, durations, numbers)
+			if !ok {
+				return Duration{}, false
+			}
+			if divisor, ok := EvaluateFloat(e.Right, numbers); ok && divisor != 0 {
+				return Duration{Value: left.Value / divisor, Unit: left.Unit}, true
+			}
+		}
+	}
+
+	return Duration{}, false
+}
@@ -0,0 +1,80 @@
+package astutil
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// QuantumTypeInfo returns the type of a quantum declaration: a qubit, with one
+// dimension for registers. Register sizes are folded with constants; ok is
+// false when the size is not a positive compile-time integer.
+func QuantumTypeInfo(decl *parser.QuantumDeclaration, constants map[string]int64) (*parser.TypeInfo, bool) {
+	if decl.TypeInfo != nil {
+		return decl.TypeInfo, true
+	}
+
+	info := &parser.TypeInfo{Kind: "qubit"}
+	if decl.Size == nil {
+		return info, true
+	}
+	size, ok := EvaluateInteger(decl.Size, constants)
+	if !ok || size < 1 {
+		return nil, false
+	}
+	info.Dimensions = []int{int(size)}
+	return info, true
+}
+
+// ClassicalTypeInfo returns the type of a classical declaration. The size of
+// a bit register becomes its dimension and that of other types, as in int[8],
+// its bit width; creg declarations are bit registers. Sizes are folded with
+// constants; ok is false when the size is not a positive compile-time integer
+// or the type is not a scalar type.
+func ClassicalTypeInfo(decl *parser.ClassicalDeclaration, constants map[string]int64) (*parser.TypeInfo, bool) {
+	if decl.TypeInfo != nil {
+		return decl.TypeInfo, true
+	}
+
+	// The parser keeps designators in the type, as in "bit[4]" or "int[n]"
+	kind, designator := decl.Type, ""
+	if open := strings.Index(kind, "["); open >= 0 && strings.HasSuffix(kind, "]") {
+		kind, designator = kind[:open], strings.TrimSpace(kind[open+1:len(kind)-1])
+	}
+	if kind == "creg" {
+		kind = "bit"
+	}
+	info := &parser.TypeInfo{Kind: kind}
+
+	var size int64
+	switch {
+	case designator != "":
+		value, err := strconv.ParseInt(designator, 10, 64)
+		if err != nil {
+			var ok bool
+			if value, ok = constants[designator]; !ok {
+				return nil, false
+			}
+		}
+		size = value
+	case decl.Size != nil:
+		value, ok := EvaluateInteger(decl.Size, constants)
+		if !ok {
+			return nil, false
+		}
+		size = value
+	default:
+		return info, true
+	}
+
+	if size < 1 {
+		return nil, false
+	}
+	if kind == "bit" {
+		info.Dimensions = []int{int(size)}
+	} else {
+		info.BitWidth = int(size)
+	}
+	return info, true
+}
@@ -0,0 +1,183 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BaselineVersion is the current baseline file format version
+const BaselineVersion = 1
+
+// Baseline records known violations so that only new ones are reported
+type Baseline struct {
+	Version    int              `json:"version"`
+	Violations []*BaselineEntry `json:"violations"`
+
+	sources map[string][]string // Cached source lines keyed by file
+}
+
+// BaselineEntry identifies a group of identical violations in a file
+type BaselineEntry struct {
+	Rule        string `json:"rule"`
+	File        string `json:"file"`
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// NewBaseline creates an empty baseline
+func NewBaseline() *Baseline {
+	return &Baseline{
+		Version:    BaselineVersion,
+		Violations: make([]*BaselineEntry, 0),
+		sources:    make(map[string][]string),
+	}
+}
+
+// LoadBaseline reads a baseline from a JSON file
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	baseline := NewBaseline()
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != BaselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d (expected %d)", baseline.Version, BaselineVersion)
+	}
+
+	return baseline, nil
+}
+
+// Save writes the baseline to a JSON file
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// SetSource provides the content of a file that cannot be read from disk (e.g. stdin)
+func (b *Baseline) SetSource(file, content string) {
+	b.sources[normalizeBaselinePath(file)] = strings.Split(content, "\n")
+}
+
+// Add records violations in the baseline
+func (b *Baseline) Add(violations []*Violation) {
+	index := make(map[string]*BaselineEntry)
+	for _, entry := range b.Violations {
+		index[baselineKey(entry.Rule, entry.File, entry.Fingerprint)] = entry
+	}
+
+	for _, v := range violations {
+		file := normalizeBaselinePath(v.File)
+		fingerprint := b.Fingerprint(v)
+		key := baselineKey(v.Rule.ID, file, fingerprint)
+
+		if entry, exists := index[key]; exists {
+			entry.Count++
+			continue
+		}
+
+		entry := &BaselineEntry{
+			Rule:        v.Rule.ID,
+			File:        file,
+			Fingerprint: fingerprint,
+			Count:       1,
+		}
+		index[key] = entry
+		b.Violations = append(b.Violations, entry)
+	}
+
+	// Keep the file stable across runs to minimize diffs
+	sort.Slice(b.Violations, func(i, j int) bool {
+		a, c := b.Violations[i], b.Violations[j]
+		if a.File != c.File {
+			return a.File < c.File
+		}
+		if a.Rule != c.Rule {
+			return a.Rule < c.Rule
+		}
+		return a.Fingerprint < c.Fingerprint
+	})
+}
+
+// Filter returns only the violations that are not recorded in the baseline.
+// Each baseline entry suppresses at most Count matching violations, so adding
+// another identical violation to a file is still reported.
+func (b *Baseline) Filter(violations []*Violation) []*Violation {
+	remaining := make(map[string]int)
+	for _, entry := range b.Violations {
+		remaining[baselineKey(entry.Rule, entry.File, entry.Fingerprint)] += entry.Count
+	}
+
+	var filtered []*Violation
+	for _, v := range violations {
+		key := baselineKey(v.Rule.ID, normalizeBaselinePath(v.File), b.Fingerprint(v))
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		filtered = append(filtered, v)
+	}
+
+	return filtered
+}
+
+// Fingerprint computes a content-based fingerprint for a violation.
+// It hashes the rule ID, the message and the whitespace-normalized text of the
+// offending line, so that violations survive unrelated edits that shift lines.
+func (b *Baseline) Fingerprint(v *Violation) string {
+	hash := sha256.New()
+	hash.Write([]byte(v.Rule.ID))
+	hash.Write([]byte{0})
+	hash.Write([]byte(v.Message))
+	hash.Write([]byte{0})
+	hash.Write([]byte(b.lineText(v.File, v.Line)))
+
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// lineText returns the normalized source text of a line, or "" if unavailable
+func (b *Baseline) lineText(file string, line int) string {
+	key := normalizeBaselinePath(file)
+
+	lines, cached := b.sources[key]
+	if !cached {
+		content, err := os.ReadFile(file)
+		if err == nil {
+			lines = strings.Split(string(content), "\n")
+		}
+		b.sources[key] = lines
+	}
+
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(RemoveComments(lines[line-1])), " ")
+}
+
+// normalizeBaselinePath makes file paths comparable across platforms
+func normalizeBaselinePath(file string) string {
+	return filepath.ToSlash(filepath.Clean(file))
+}
+
+// baselineKey builds the lookup key for a baseline entry
+func baselineKey(rule, file, fingerprint string) string {
+	return rule + "|" + file + "|" + fingerprint
+}
@@ -0,0 +1,84 @@
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBaselineFilter(t *testing.T) {
+	original := `OPENQASM 3.0;
+qubit[2] MyQ;
+qubit unused;
+h MyQ[0];`
+
+	linter := NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	violations, err := linter.LintContent(original, "legacy.qasm")
+	if err != nil {
+		t.Fatalf("Failed to lint content: %v", err)
+	}
+	if len(violations) == 0 {
+		t.Fatal("Expected violations in legacy code")
+	}
+
+	baseline := NewBaseline()
+	baseline.SetSource("legacy.qasm", original)
+	baseline.Add(violations)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := baseline.Save(path); err != nil {
+		t.Fatalf("Failed to save baseline: %v", err)
+	}
+
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Failed to load baseline: %v", err)
+	}
+
+	// Inserting a line shifts existing violations but must not resurface them
+	modified := `OPENQASM 3.0;
+qubit extra;
+qubit[2] MyQ;
+qubit unused;
+h MyQ[0];`
+
+	violations, err = linter.LintContent(modified, "legacy.qasm")
+	if err != nil {
+		t.Fatalf("Failed to lint content: %v", err)
+	}
+
+	loaded.SetSource("legacy.qasm", modified)
+	remaining := loaded.Filter(violations)
+
+	if len(remaining) != 1 {
+		for _, v := range remaining {
+			t.Logf("Violation: %s", v.String())
+		}
+		t.Fatalf("Expected 1 new violation, got %d", len(remaining))
+	}
+	if remaining[0].Rule.ID != "QAS001" || remaining[0].Line != 2 {
+		t.Errorf("Expected new QAS001 violation on line 2, got %s", remaining[0].String())
+	}
+}
+
+func TestBaselineCountsDuplicates(t *testing.T) {
+	content := "qubit A;\nqubit A;\n"
+	v1 := &Violation{Rule: &Rule{ID: "QAS012"}, Message: "m", File: "f.qasm", Line: 1}
+	v2 := &Violation{Rule: &Rule{ID: "QAS012"}, Message: "m", File: "f.qasm", Line: 2}
+
+	baseline := NewBaseline()
+	baseline.SetSource("f.qasm", content)
+	baseline.Add([]*Violation{v1})
+
+	if len(baseline.Violations) != 1 || baseline.Violations[0].Count != 1 {
+		t.Fatalf("Expected a single entry with count 1, got %+v", baseline.Violations)
+	}
+
+	remaining := baseline.Filter([]*Violation{v1, v2})
+	if len(remaining) != 1 {
+		t.Errorf("Expected the duplicate violation to be reported, got %d", len(remaining))
+	}
+}
@@ -0,0 +1,299 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// CacheVersion is the current cache entry format version
+const CacheVersion = 1
+
+// Cache stores lint results on disk so that a file is only analyzed again when
+// its content, the content of a file it includes or the linter configuration
+// changes. Entries are keyed by the file's absolute path, a hash of its content
+// and the linter fingerprint, which covers the qasm binary, the loaded rules
+// and the target profile. A Cache is safe for concurrent use.
+type Cache struct {
+	dir    string
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cacheEntry is the lint result of one file as stored on disk
+type cacheEntry struct {
+	Version    int             `json:"version"`
+	File       string          `json:"file"`
+	Includes   []cachedInclude `json:"includes"`
+	Violations []*Violation    `json:"violations"`
+}
+
+// cachedInclude records the content hash of an included file when the entry
+// was stored; the hash is empty when the file did not exist
+type cachedInclude struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+// NewCache opens the cache in dir, creating the directory if needed
+func NewCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// DefaultCacheDir returns the cache directory used when none is given
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "qasmtools", "lint"), nil
+}
+
+// Stats returns the number of lookups that were answered from the cache and
+// the number that were not
+func (c *Cache) Stats() (hits, misses int) {
+	return int(c.hits.Load()), int(c.misses.Load())
+}
+
+// Clear removes all entries from the cache
+func (c *Cache) Clear() error {
+	entries, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Remove(entry); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// key returns the entry key of a file's content under a linter fingerprint
+func (c *Cache) key(filename, content, fingerprint string) string {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		path = filename
+	}
+	return hashStrings(fmt.Sprint(CacheVersion), fingerprint, path, hashStrings(content))
+}
+
+// lookup returns the cached violations for key when no included file has
+// changed since they were stored. Rules are looked up by ID in rules so that
+// cached violations share the linter's rule metadata.
+func (c *Cache) lookup(key string, rules []*Rule) ([]*Violation, bool) {
+	entry, ok := c.read(key)
+	if !ok || !c.valid(entry, rules) {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	byID := make(map[string]*Rule, len(rules))
+	for _, rule := range rules {
+		byID[rule.ID] = rule
+	}
+	for _, v := range entry.Violations {
+		v.Rule = byID[v.Rule.ID]
+	}
+
+	c.hits.Add(1)
+	return entry.Violations, true
+}
+
+// read loads an entry, reporting false when it is missing or unreadable
+func (c *Cache) read(key string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Version != CacheVersion {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// valid reports whether the included files of an entry are unchanged and its
+// violations refer to loaded rules
+func (c *Cache) valid(entry *cacheEntry, rules []*Rule) bool {
+	for _, include := range entry.Includes {
+		if hashFile(include.Path) != include.Hash {
+			return false
+		}
+	}
+
+	loaded := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		loaded[rule.ID] = true
+	}
+	for _, v := range entry.Violations {
+		if v.Rule == nil || !loaded[v.Rule.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// store records the violations of a file together with the hashes of the
+// files it includes. Every violation must have its rule set.
+func (c *Cache) store(key, filename string, program *parser.Program, violations []*Violation) {
+	entry := &cacheEntry{
+		Version:    CacheVersion,
+		File:       filename,
+		Includes:   cacheIncludes(filename, program, make(map[string]bool)),
+		Violations: make([]*Violation, len(violations)),
+	}
+	// Rule metadata is part of the fingerprint, so only the ID is stored
+	for i, v := range violations {
+		stored := *v
+		stored.Rule = &Rule{ID: v.Rule.ID}
+		entry.Violations[i] = &stored
+	}
+
+	c.write(key, entry)
+}
+
+// projectFileKey returns the key of the project summary of a file's content.
+// Summaries do not depend on the path or the rules, only on the content and
+// the binary building them.
+func (c *Cache) projectFileKey(content string) string {
+	return hashStrings(fmt.Sprint(CacheVersion), "project", buildID(), hashStrings(content))
+}
+
+// lookupProjectFile returns the cached project summary of a file's content.
+// Summaries are not counted in Stats, which reports whole files.
+func (c *Cache) lookupProjectFile(content string) (*ProjectFile, bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, c.projectFileKey(content)+".json"))
+	if err != nil {
+		return nil, false
+	}
+	var file ProjectFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, false
+	}
+	return &file, true
+}
+
+// storeProjectFile records the project summary of a file's content
+func (c *Cache) storeProjectFile(content string, file *ProjectFile) {
+	c.write(c.projectFileKey(content), file)
+}
+
+// write stores a value under key. Failing to write the cache does not fail
+// linting, so errors are ignored.
+func (c *Cache) write(key string, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a
+	// partial entry
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, key+".json")); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// cacheIncludes returns the hashes of the files a program includes, directly
+// or through other included files. Paths are resolved relative to the
+// including file; files that cannot be read are recorded with an empty hash so
+// that creating them invalidates the entry.
+func cacheIncludes(filename string, program *parser.Program, visited map[string]bool) []cachedInclude {
+	var includes []cachedInclude
+
+	for _, include := range astutil.FindNodesByType(program, (*parser.Include)(nil)) {
+		path := filepath.Join(filepath.Dir(filename), include.Path)
+		if visited[path] {
+			continue
+		}
+		visited[path] = true
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			includes = append(includes, cachedInclude{Path: path})
+			continue
+		}
+		includes = append(includes, cachedInclude{Path: path, Hash: hashStrings(string(content))})
+
+		result := parseSource(string(content))
+		if result.Program != nil {
+			includes = append(includes, cacheIncludes(path, result.Program, visited)...)
+		}
+	}
+
+	return includes
+}
+
+// fingerprint identifies the linter configuration: the running binary, which
+// changes with the rule implementations, the loaded rules with their settings,
+// the target profile and whether AST rules are used
+func (l *Linter) fingerprint() string {
+	rules, _ := json.Marshal(l.rules)
+	target, _ := json.Marshal(l.target)
+	return hashStrings(buildID(), string(rules), string(target), fmt.Sprint(l.useAST))
+}
+
+// cacheFingerprint returns the fingerprint for the cache keys of a run, or ""
+// without a cache
+func (l *Linter) cacheFingerprint() string {
+	if l.cache == nil {
+		return ""
+	}
+	return l.fingerprint()
+}
+
+// buildID identifies the running binary by the hash of its executable, or by
+// its build information when the executable cannot be read
+var buildID = sync.OnceValue(func() string {
+	if path, err := os.Executable(); err == nil {
+		if hash := hashFile(path); hash != "" {
+			return hash
+		}
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.String()
+	}
+	return ""
+})
+
+// hashFile returns the content hash of a file, or "" when it cannot be read
+func hashFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return hashStrings(string(content))
+}
+
+// hashStrings returns the hex SHA-256 of the given strings, each terminated by
+// a NUL byte so that different splits hash differently
+func hashStrings(values ...string) string {
+	h := sha256.New()
+	for _, value := range values {
+		h.Write([]byte(value))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// lintCached lints a file with a linter using cache and returns the violations
+func lintCached(t *testing.T, cache *Cache, target *Target, file string) []*Violation {
+	t.Helper()
+
+	linter := NewLinter("")
+	linter.SetTarget(target)
+	linter.SetCache(cache)
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	violations, err := linter.LintFile(file)
+	if err != nil {
+		t.Fatalf("Failed to lint %s: %v", file, err)
+	}
+	return violations
+}
+
+// writeFile writes content to a file in dir and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	writeFile(t, dir, "lib.inc", "gate flip a {\n    U(pi, 0, pi) a;\n}\n")
+	file := writeFile(t, dir, "main.qasm", "OPENQASM 3.0;\ninclude \"lib.inc\";\nqubit[2] q;\nqubit unused;\nflip q[2];\n")
+
+	expectStats := func(step string, hits, misses int) {
+		t.Helper()
+		if h, m := cache.Stats(); h != hits || m != misses {
+			t.Errorf("%s: Stats() = %d hits, %d misses, want %d, %d", step, h, m, hits, misses)
+		}
+	}
+
+	first := lintCached(t, cache, nil, file)
+	expectStats("first run", 0, 1)
+
+	second := lintCached(t, cache, nil, file)
+	expectStats("unchanged file", 1, 1)
+	if len(second) != len(first) || len(first) == 0 {
+		t.Fatalf("Expected %d cached violations, got %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i].String() != second[i].String() || first[i].EndColumn != second[i].EndColumn {
+			t.Errorf("Cached violation %d = %s, want %s", i, second[i], first[i])
+		}
+		if second[i].Rule.Name == "" {
+			t.Errorf("Cached violation %d is missing its rule metadata", i)
+		}
+	}
+
+	writeFile(t, dir, "lib.inc", "gate flip a {\n    U(pi, 0, pi) a;\n}\ngate other a {\n}\n")
+	lintCached(t, cache, nil, file)
+	expectStats("changed include", 1, 2)
+
+	lintCached(t, cache, &Target{Name: "device", Qubits: 2}, file)
+	expectStats("changed configuration", 1, 3)
+
+	writeFile(t, dir, "main.qasm", "OPENQASM 3.0;\ninclude \"lib.inc\";\nqubit[2] q;\nflip q[0];\n")
+	lintCached(t, cache, nil, file)
+	expectStats("changed content", 1, 4)
+
+	lintCached(t, cache, nil, file)
+	expectStats("unchanged again", 2, 4)
+
+	if err := cache.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	lintCached(t, cache, nil, file)
+	expectStats("cleared cache", 2, 5)
+}
+
+func TestCacheLintFilesWithoutParsing(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCache(filepath.Join(dir, "cache"))
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	main := writeFile(t, dir, "main.qasm", "OPENQASM 3.0;\ninclude \"gates.inc\";\nqubit[2] q;\nbell q[0], q[1];\n")
+	gates := writeFile(t, dir, "gates.inc", "include \"helpers.inc\";\ngate bell a, b { flip a; }\ngate unused a { flip a; }\n")
+	writeFile(t, dir, "helpers.inc", "gate flip a { U(pi, 0, pi) a; }\n")
+	other := writeFile(t, dir, "other.qasm", "OPENQASM 3.0;\nqubit q;\nU(0, 0, 0) q;\n")
+
+	parses := 0
+	parse := parseSource
+	parseSource = func(content string) *parser.ParseResult {
+		parses++
+		return parse(content)
+	}
+	t.Cleanup(func() { parseSource = parse })
+
+	lintFiles := func(step string) []*Violation {
+		t.Helper()
+		parses = 0
+		linter := NewLinter("")
+		linter.SetCache(cache)
+		if err := linter.LoadRules(); err != nil {
+			t.Fatalf("Failed to load rules: %v", err)
+		}
+		violations, err := linter.LintFiles([]string{main, gates, other})
+		if err != nil {
+			t.Fatalf("%s: LintFiles() error = %v", step, err)
+		}
+		if got := countRule(violations, "QAS028"); got != 1 {
+			t.Errorf("%s: got %d QAS028 violations, want 1", step, got)
+		}
+		return violations
+	}
+
+	first := lintFiles("first run")
+	if parses == 0 {
+		t.Errorf("first run: parsed no files")
+	}
+
+	second := lintFiles("unchanged files")
+	if parses != 0 {
+		t.Errorf("unchanged files: parsed %d times, want 0", parses)
+	}
+	if len(second) != len(first) {
+		t.Errorf("unchanged files: got %d violations, want %d", len(second), len(first))
+	}
+
+	writeFile(t, dir, "other.qasm", "OPENQASM 3.0;\nqubit q;\nU(pi, 0, 0) q;\n")
+	lintFiles("changed file")
+	if parses != 1 {
+		t.Errorf("changed file: parsed %d times, want 1", parses)
+	}
+}
@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CodeFrame renders the source line of a violation with its span underlined:
+//
+//	6 | cx q[0], q[3];
+//	  |          ^^^^
+//
+// Spans that continue on later lines are underlined to the end of the code on
+// the first line. mark styles the underline and may be nil. An empty string is returned
+// when the violation's line is not in source.
+func CodeFrame(source string, v *Violation, mark func(string) string) string {
+	lines := strings.Split(source, "\n")
+	if v.Line < 1 || v.Line > len(lines) {
+		return ""
+	}
+
+	line := []rune(strings.TrimRight(lines[v.Line-1], "\r"))
+	start := min(max(v.Column, 1), len(line)+1)
+	end := start
+	switch {
+	case v.EndLine > v.Line:
+		end = max(codeEnd(line), start)
+	case v.EndLine == v.Line && v.EndColumn >= start:
+		end = min(v.EndColumn, max(len(line), start))
+	}
+
+	// Keep tabs in the padding so the underline lines up with the source
+	padding := make([]rune, start-1)
+	for i := range padding {
+		padding[i] = ' '
+		if line[i] == '\t' {
+			padding[i] = '\t'
+		}
+	}
+	underline := strings.Repeat("^", end-start+1)
+	if mark != nil {
+		underline = mark(underline)
+	}
+
+	number := fmt.Sprint(v.Line)
+	gutter := strings.Repeat(" ", len(number))
+	return fmt.Sprintf(" %s | %s\n %s | %s%s\n", number, string(line), gutter, string(padding), underline)
+}
+
+// codeEnd returns the column of the last character of a line before any
+// trailing comment and whitespace
+func codeEnd(line []rune) int {
+	code := string(line)
+	if i := strings.Index(code, "//"); i >= 0 {
+		code = code[:i]
+	}
+	return len([]rune(strings.TrimRight(code, " \t")))
+}
@@ -0,0 +1,52 @@
+package lint
+
+import "testing"
+
+func TestCodeFrame(t *testing.T) {
+	source := "qubit[2] q;\n\tcx q[0], q[2];\nif (c) {  // branch\n  x q;\n}"
+
+	tests := []struct {
+		name      string
+		violation *Violation
+		expected  string
+	}{
+		{
+			name:      "single line span",
+			violation: &Violation{Line: 2, Column: 11, EndLine: 2, EndColumn: 14},
+			expected:  " 2 | \tcx q[0], q[2];\n   | \t         ^^^^\n",
+		},
+		{
+			name:      "no end position",
+			violation: &Violation{Line: 1, Column: 7},
+			expected:  " 1 | qubit[2] q;\n   |       ^\n",
+		},
+		{
+			name:      "multi-line span stops before comment",
+			violation: &Violation{Line: 3, Column: 1, EndLine: 5, EndColumn: 1},
+			expected:  " 3 | if (c) {  // branch\n   | ^^^^^^^^\n",
+		},
+		{
+			name:      "end past line is clamped",
+			violation: &Violation{Line: 1, Column: 10, EndLine: 1, EndColumn: 40},
+			expected:  " 1 | qubit[2] q;\n   |          ^^\n",
+		},
+		{
+			name:      "line outside source",
+			violation: &Violation{Line: 9, Column: 1},
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeFrame(source, tt.violation, nil); got != tt.expected {
+				t.Errorf("CodeFrame() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	marked := CodeFrame(source, &Violation{Line: 1, Column: 1}, func(s string) string { return "<" + s + ">" })
+	if expected := " 1 | qubit[2] q;\n   | <^>\n"; marked != expected {
+		t.Errorf("CodeFrame() with mark = %q, want %q", marked, expected)
+	}
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"os"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// CheckContext provides context for rule checking
+type CheckContext struct {
+	File     string
+	Content  string // Raw file content for text-based analysis
+	Program  *parser.Program
+	UsageMap map[string][]parser.Node // For tracking symbol usage
+	Rule     *Rule                    // Metadata of the rule being checked, including its configured checks
+	Target   *Target                  // Hardware profile to check against, nil when none was given
+}
+
+// GetContent returns the content for analysis, preferring provided content over file reading
+func (c *CheckContext) GetContent() (string, error) {
+	if c.Content != "" {
+		return c.Content, nil
+	}
+
+	content, err := os.ReadFile(c.File)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
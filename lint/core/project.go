@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// Project holds the files of a lint run together with the files they include,
+// so that rules can check definitions and uses across files
+type Project struct {
+	// Files are the linted files in the order they were given, followed by
+	// the files only reached through include statements
+	Files []*ProjectFile
+}
+
+// ProjectFile is a file of a project, summarized to what project rules check:
+// its global gate definitions, the gates it calls and its include statements.
+// Summaries only depend on the file's content, so they can be cached and a
+// project checked without parsing unchanged files.
+type ProjectFile struct {
+	Path     string            `json:"-"` // Path as given for linted files, or resolved from the including file
+	Linted   bool              `json:"-"` // Whether the file is one of the linted files rather than only included
+	Gates    []*ProjectGate    `json:"gates"`
+	Calls    []string          `json:"calls"` // Gates called outside their own definition, sorted by name
+	Includes []*ProjectInclude `json:"includes"`
+}
+
+// ProjectGate is a global gate definition of a project file. It is a
+// parser.Node located at the definition.
+type ProjectGate struct {
+	parser.BaseNode
+	Name       string `json:"name"`
+	Parameters int    `json:"parameters"`
+	Qubits     int    `json:"qubits"`
+	// Includes is the number of include statements preceding the definition
+	Includes int `json:"includes"`
+}
+
+// String returns the name of the gate
+func (g *ProjectGate) String() string {
+	return g.Name
+}
+
+// ProjectInclude is an include statement resolved to the file it includes. It
+// is a parser.Node located at the statement.
+type ProjectInclude struct {
+	parser.BaseNode
+	Path string `json:"path"` // Path as written in the statement
+	// File is the included file, nil for well-known include files such as
+	// stdgates.inc and for files that cannot be read or parsed
+	File *ProjectFile `json:"-"`
+}
+
+// String returns the include statement
+func (i *ProjectInclude) String() string {
+	return fmt.Sprintf("include %q;", i.Path)
+}
+
+// Includers returns the files with an include statement resolved to file
+func (p *Project) Includers(file *ProjectFile) []*ProjectFile {
+	var includers []*ProjectFile
+	for _, candidate := range p.Files {
+		for _, include := range candidate.Includes {
+			if include.File == file {
+				includers = append(includers, candidate)
+				break
+			}
+		}
+	}
+	return includers
+}
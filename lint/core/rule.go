@@ -0,0 +1,100 @@
+// Package core defines the data model shared by the linter engine, the
+// AST-based rules and every consumer of lint results (CLI, LSP and WASM):
+// rules, severities, violations with their fixes, and the check context.
+package core
+
+// Severity represents the severity level of a lint rule
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// rank orders severities from least to most severe; unknown severities rank 0
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AtMost returns s lowered to limit when s is more severe than limit.
+// A rule's level is the highest severity its violations are reported with.
+func (s Severity) AtMost(limit Severity) Severity {
+	if s.rank() == 0 || s.rank() > limit.rank() {
+		return limit
+	}
+	return s
+}
+
+// AtLeast reports whether s is at least as severe as limit
+func (s Severity) AtLeast(limit Severity) bool {
+	return s.rank() >= limit.rank()
+}
+
+// Valid reports whether s is one of the known severities
+func (s Severity) Valid() bool {
+	return s.rank() > 0
+}
+
+// Rule represents a lint rule loaded from YAML
+type Rule struct {
+	ID               string   `yaml:"id"`
+	Name             string   `yaml:"name"`
+	Description      string   `yaml:"description"`
+	Level            Severity `yaml:"level"`
+	Enabled          bool     `yaml:"enabled"`
+	Match            Match    `yaml:"match"`
+	Check            []Check  `yaml:"check"`
+	Message          string   `yaml:"message"`
+	Tags             []string `yaml:"tags"`
+	Fixable          bool     `yaml:"fixable"`
+	DocumentationURL string   `yaml:"documentation_url"`
+	SpecificationURL string   `yaml:"specification_url"`
+	Examples         Examples `yaml:"examples"`
+}
+
+// Match defines what AST nodes to match
+type Match struct {
+	Type string `yaml:"type"` // declaration, statement, expression, etc.
+	Kind string `yaml:"kind"` // qubit, gate, measure, etc.
+}
+
+// Check defines what to check on matched nodes
+type Check struct {
+	Type     string `yaml:"type"`      // usage, naming, count, etc.
+	Target   string `yaml:"target"`    // what is checked, e.g. the resource a count check limits
+	NotFound bool   `yaml:"not_found"` // for usage checks
+	Pattern  string `yaml:"pattern"`   // for naming checks
+	Max      int    `yaml:"max"`       // for count checks
+	Min      int    `yaml:"min"`       // for count checks
+}
+
+// Examples contains code examples for the rule
+type Examples struct {
+	Incorrect string `yaml:"incorrect"`
+	Correct   string `yaml:"correct"`
+}
+
+// Limits returns the maximum of each count check keyed by its target.
+// Count checks without a positive max are left out; a nil rule has no limits.
+func (r *Rule) Limits() map[string]int {
+	limits := make(map[string]int)
+	if r == nil {
+		return limits
+	}
+	for _, check := range r.Check {
+		if check.Type == "count" && check.Max > 0 {
+			limits[check.Target] = check.Max
+		}
+	}
+	return limits
+}
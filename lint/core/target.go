@@ -0,0 +1,43 @@
+package core
+
+// Target describes the hardware a program is compiled for: the gates the
+// device executes natively, its number of physical qubits and which pairs of
+// physical qubits can interact. Zero values mean "unconstrained".
+type Target struct {
+	Name        string   `yaml:"name" json:"name"`
+	Qubits      int      `yaml:"qubits" json:"qubits"`
+	NativeGates []string `yaml:"native_gates" json:"native_gates"`
+	CouplingMap [][2]int `yaml:"coupling_map" json:"coupling_map"`
+	Directed    bool     `yaml:"directed" json:"directed"` // Edges only allow control -> target in the listed order
+}
+
+// IsNative reports whether the target executes the named gate directly.
+// Every gate is native when no native gate set is given.
+func (t *Target) IsNative(name string) bool {
+	if len(t.NativeGates) == 0 {
+		return true
+	}
+	for _, gate := range t.NativeGates {
+		if gate == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Coupled reports whether a two-qubit gate can act on physical qubits a and b.
+// Every pair is coupled when no coupling map is given.
+func (t *Target) Coupled(a, b int) bool {
+	if len(t.CouplingMap) == 0 {
+		return true
+	}
+	for _, edge := range t.CouplingMap {
+		if edge[0] == a && edge[1] == b {
+			return true
+		}
+		if !t.Directed && edge[0] == b && edge[1] == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// Tag classifies a violation so that editors can render it specially
+type Tag string
+
+const (
+	// TagUnnecessary marks unused or unreachable code, usually rendered faded
+	TagUnnecessary Tag = "unnecessary"
+	// TagDeprecated marks deprecated constructs, usually rendered struck through
+	TagDeprecated Tag = "deprecated"
+)
+
+// Violation represents a rule violation. Line and Column give the 1-based
+// start; EndLine and EndColumn give the line and column of the last character
+// of the offending source and are zero when the extent is unknown.
+type Violation struct {
+	Rule      *Rule
+	Message   string
+	File      string
+	Line      int
+	Column    int
+	EndLine   int `json:",omitempty"`
+	EndColumn int `json:",omitempty"`
+	Severity  Severity
+	NodeName  string
+	Tags      []Tag             `json:",omitempty"`
+	Related   []RelatedLocation `json:",omitempty"`
+	Fix       *Fix              `json:",omitempty"`
+}
+
+// HasTag reports whether the violation carries the given tag
+func (v *Violation) HasTag(tag Tag) bool {
+	for _, t := range v.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a formatted string representation of the violation
+func (v *Violation) String() string {
+	result := fmt.Sprintf("%s:%d:%d: %s [%s] %s",
+		v.File, v.Line, v.Column, v.Severity, v.Rule.ID, v.Message)
+
+	if v.Rule.DocumentationURL != "" {
+		result += fmt.Sprintf(" (%s)", v.Rule.DocumentationURL)
+	}
+
+	return result
+}
+
+// RelatedLocation points to another source location that explains a violation,
+// such as the original declaration of a redeclared identifier
+type RelatedLocation struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Fix describes an automatic correction for a violation
+type Fix struct {
+	Description string
+	Edits       []TextEdit
+}
+
+// TextEdit replaces the source between Start and End with NewText.
+// Offsets count characters (runes) from the start of the file.
+type TextEdit struct {
+	Start   parser.Position
+	End     parser.Position
+	NewText string
+}
@@ -295,6 +295,42 @@ func (dg *DocumentationGenerator) generateRelatedRules(rule *Rule) string {
 		return "- [QAS005](QAS005.md) (naming-convention-violation): Both relate to identifier naming\n- [QAS012](QAS012.md) (snake-case-required): Both relate to naming standards\n"
 	case "QAS012":
 		return "- [QAS005](QAS005.md) (naming-convention-violation): Both relate to naming conventions\n- [QAS011](QAS011.md) (reserved-prefix-usage): Both relate to naming standards\n"
+	case "QAS030":
+		return "- [QAS004](QAS004.md) (out-of-bounds-index): Both check register indices and slices\n- [QAS003](QAS003.md) (constant-measured-bit): Both relate to measurement operations\n- [QAS006](QAS006.md) (gate-register-size-mismatch): Both compare register sizes\n"
+	case "QAS029":
+		return "- [QAS015](QAS015.md) (gate-signature-mismatch): Both compare gate signatures\n- [QAS016](QAS016.md) (identifier-redeclaration): Both report names defined twice\n- [QAS028](QAS028.md) (unused-library-gate): Both check gate libraries across files\n"
+	case "QAS028":
+		return "- [QAS020](QAS020.md) (unused-declaration): Both report gates that are never used\n- [QAS029](QAS029.md) (conflicting-gate-definition): Both check gate libraries across files\n"
+	case "QAS027":
+		return "- [QAS026](QAS026.md) (unsupported-on-target): Both check that a program fits the hardware it runs on\n- [QAS019](QAS019.md) (unreachable-code): Both analyze loop structure\n"
+	case "QAS026":
+		return "- [QAS015](QAS015.md) (gate-signature-mismatch): Both validate gate calls\n- [QAS004](QAS004.md) (out-of-bounds-index): Both check qubit indices against available qubits\n"
+	case "QAS025":
+		return "- [QAS023](QAS023.md) (invalid-duration): Both relate to timing constructs\n- [QAS018](QAS018.md) (classical-read-before-assignment): Both relate to how classical variables are used\n"
+	case "QAS024":
+		return "- [QAS023](QAS023.md) (invalid-duration): Both validate delay and box durations\n- [QAS025](QAS025.md) (stretch-outside-timing): Both relate to timing constructs\n"
+	case "QAS023":
+		return "- [QAS024](QAS024.md) (box-duration-exceeded): Both validate delay and box durations\n- [QAS025](QAS025.md) (stretch-outside-timing): Both relate to timing constructs\n"
+	case "QAS022":
+		return "- [QAS015](QAS015.md) (gate-signature-mismatch): Both validate gate call parameters\n- [QAS019](QAS019.md) (unreachable-code): Both fold constant expressions\n"
+	case "QAS021":
+		return "- [QAS002](QAS002.md) (undefined-identifier): Both relate to gate resolution\n- [QAS020](QAS020.md) (unused-declaration): Both offer automatic fixes\n"
+	case "QAS020":
+		return "- [QAS001](QAS001.md) (unused-qubit): Both report declarations that are never used\n- [QAS019](QAS019.md) (unreachable-code): Both report dead code\n"
+	case "QAS019":
+		return "- [QAS009](QAS009.md) (illegal-break-continue): Both relate to loop control flow\n- [QAS018](QAS018.md) (classical-read-before-assignment): Both use flow-sensitive analysis\n"
+	case "QAS018":
+		return "- [QAS003](QAS003.md) (constant-measured-bit): Both detect values that are not meaningfully set\n- [QAS013](QAS013.md) (qubit-used-after-measurement): Both use flow-sensitive analysis\n"
+	case "QAS017":
+		return "- [QAS010](QAS010.md) (invalid-instruction-in-gate): Both relate to gate definition bodies\n- [QAS015](QAS015.md) (gate-signature-mismatch): Both relate to calls between gates\n"
+	case "QAS016":
+		return "- [QAS002](QAS002.md) (undefined-identifier): Both relate to identifier resolution\n- [QAS008](QAS008.md) (qubit-declared-in-local-scope): Both relate to declaration scopes\n"
+	case "QAS015":
+		return "- [QAS006](QAS006.md) (gate-register-size-mismatch): Both relate to gate operand validation\n- [QAS002](QAS002.md) (undefined-identifier): Both relate to gate resolution\n"
+	case "QAS014":
+		return "- [QAS006](QAS006.md) (gate-register-size-mismatch): Both relate to gate operand validation\n- [QAS004](QAS004.md) (out-of-bounds-index): Both relate to register indexing\n"
+	case "QAS013":
+		return "- [QAS003](QAS003.md) (constant-measured-bit): Both relate to measurement operations\n- [QAS010](QAS010.md) (invalid-instruction-in-gate): Both relate to non-unitary operations\n"
 	default:
 		return "None currently identified.\n"
 	}
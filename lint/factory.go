@@ -16,8 +16,17 @@ func CreateChecker(rule *Rule) RuleChecker {
 	}
 }
 
-// CreateASTRule creates AST-based rules for improved analysis
+// CreateASTRule creates the AST-based rule with the given ID, either a built-in
+// rule or one added through RegisterRule. It returns nil for unknown IDs.
 func CreateASTRule(ruleID string) ast.ASTRule {
+	if rule := createBuiltinRule(ruleID); rule != nil {
+		return rule
+	}
+	return createRegisteredRule(ruleID)
+}
+
+// createBuiltinRule creates the built-in AST-based rules
+func createBuiltinRule(ruleID string) ast.ASTRule {
 	switch ruleID {
 	case "QAS001":
 		return ast.NewUnusedQubitRule()
@@ -43,7 +52,43 @@ func CreateASTRule(ruleID string) ast.ASTRule {
 		return ast.NewQAS009IllegalBreakContinueRule()
 	case "QAS012":
 		return ast.NewQAS012SnakeCaseRequiredRule()
-	// All 12 rules now have AST implementations
+	case "QAS013":
+		return ast.NewQubitUsedAfterMeasurementRule()
+	case "QAS014":
+		return ast.NewDuplicateQubitOperandRule()
+	case "QAS015":
+		return ast.NewGateSignatureMismatchRule()
+	case "QAS016":
+		return ast.NewIdentifierRedeclarationRule()
+	case "QAS017":
+		return ast.NewRecursiveGateDefinitionRule()
+	case "QAS018":
+		return ast.NewClassicalReadBeforeAssignmentRule()
+	case "QAS019":
+		return ast.NewUnreachableCodeRule()
+	case "QAS020":
+		return ast.NewUnusedDeclarationRule()
+	case "QAS021":
+		return ast.NewDeprecatedQASM2ConstructRule()
+	case "QAS022":
+		return ast.NewSuspiciousAngleRule()
+	case "QAS023":
+		return ast.NewInvalidDurationRule()
+	case "QAS024":
+		return ast.NewBoxDurationExceededRule()
+	case "QAS025":
+		return ast.NewStretchOutsideTimingRule()
+	case "QAS026":
+		return ast.NewUnsupportedOnTargetRule()
+	case "QAS027":
+		return ast.NewResourceLimitExceededRule()
+	case "QAS028":
+		return ast.NewUnusedLibraryGateRule()
+	case "QAS029":
+		return ast.NewConflictingGateDefinitionRule()
+	case "QAS030":
+		return ast.NewMeasurementRegisterMismatchRule()
+	// All rules have AST implementations
 	default:
 		return nil
 	}
@@ -0,0 +1,60 @@
+package lint
+
+import (
+	"sort"
+)
+
+// ApplyFixes applies the fixes of violations to content and returns the corrected
+// content and the number of fixes applied. A fix whose edits overlap an already
+// applied fix is skipped; running the linter again will offer it once more.
+func ApplyFixes(content string, violations []*Violation) (string, int) {
+	var fixes []*Fix
+	for _, v := range violations {
+		if v.Fix != nil && len(v.Fix.Edits) > 0 {
+			fixes = append(fixes, v.Fix)
+		}
+	}
+
+	// Select fixes in source order, skipping those that overlap an earlier one
+	sort.SliceStable(fixes, func(i, j int) bool {
+		return fixes[i].Edits[0].Start.Offset < fixes[j].Edits[0].Start.Offset
+	})
+
+	var edits []TextEdit
+	applied := 0
+	for _, fix := range fixes {
+		if overlapsAny(fix.Edits, edits) {
+			continue
+		}
+		edits = append(edits, fix.Edits...)
+		applied++
+	}
+
+	// Apply from the end of the file so earlier offsets stay valid
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Start.Offset > edits[j].Start.Offset
+	})
+
+	runes := []rune(content)
+	for _, edit := range edits {
+		start, end := edit.Start.Offset, edit.End.Offset
+		if start < 0 || end > len(runes) || start > end {
+			continue
+		}
+		runes = append(runes[:start], append([]rune(edit.NewText), runes[end:]...)...)
+	}
+
+	return string(runes), applied
+}
+
+// overlapsAny reports whether any edit in candidates overlaps an edit in accepted
+func overlapsAny(candidates, accepted []TextEdit) bool {
+	for _, c := range candidates {
+		for _, a := range accepted {
+			if c.Start.Offset < a.End.Offset && a.Start.Offset < c.End.Offset {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// edit creates a text edit between two rune offsets
+func edit(start, end int, text string) TextEdit {
+	return TextEdit{
+		Start:   parser.Position{Offset: start},
+		End:     parser.Position{Offset: end},
+		NewText: text,
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		violations []*Violation
+		expected   string
+		applied    int
+	}{
+		{
+			name:    "no fixes",
+			content: "qubit q;",
+			violations: []*Violation{
+				{Message: "no fix"},
+			},
+			expected: "qubit q;",
+		},
+		{
+			name:    "multiple fixes in reverse order",
+			content: "abcdef",
+			violations: []*Violation{
+				{Fix: &Fix{Edits: []TextEdit{edit(4, 5, "E")}}},
+				{Fix: &Fix{Edits: []TextEdit{edit(0, 1, "")}}},
+			},
+			expected: "bcdEf",
+			applied:  2,
+		},
+		{
+			name:    "overlapping fix is skipped",
+			content: "abcdef",
+			violations: []*Violation{
+				{Fix: &Fix{Edits: []TextEdit{edit(1, 4, "")}}},
+				{Fix: &Fix{Edits: []TextEdit{edit(2, 3, "X")}}},
+			},
+			expected: "aef",
+			applied:  1,
+		},
+		{
+			name:    "offsets are rune indices",
+			content: "// θ\nqubit q;",
+			violations: []*Violation{
+				{Fix: &Fix{Edits: []TextEdit{edit(5, 13, "qubit r;")}}},
+			},
+			expected: "// θ\nqubit r;",
+			applied:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, applied := ApplyFixes(tt.content, tt.violations)
+			if fixed != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, fixed)
+			}
+			if applied != tt.applied {
+				t.Errorf("Expected %d fixes applied, got %d", tt.applied, applied)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/orangekame3/qasmtools/lint/linttest"
+)
+
+func TestGoldenFixtures(t *testing.T) {
+	linttest.RunDir(t, "../testdata/lint")
+}
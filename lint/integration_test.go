@@ -27,8 +27,8 @@ func TestLintIntegration(t *testing.T) {
 		{
 			name:               "naming convention violations",
 			file:               "testdata/violations/naming_violation.qasm",
-			expectedViolations: 3, // QAS001: BadName unused, QAS005: BadName naming, QAS012: BadName snake_case (MyGate not parsed)
-			expectedRuleIDs:    []string{"QAS001", "QAS005", "QAS012"},
+			expectedViolations: 6, // QAS001: BadName unused, QAS005 and QAS012: BadName and MyGate naming, QAS020: MyGate unused
+			expectedRuleIDs:    []string{"QAS001", "QAS005", "QAS012", "QAS020"},
 		},
 		{
 			name:               "array bounds violations",
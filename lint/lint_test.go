@@ -34,13 +34,12 @@ measure q->c;`
 		t.Logf("Violation: %s", v.String())
 		if v.Rule.ID == "QAS004" {
 			found = true
-			// AST parser currently reports all IndexedIdentifier positions as line 1, col 1
-			// This is a known parser limitation - the violation is correctly detected
-			if v.Line != 1 {
-				t.Errorf("Expected violation on line 1 (parser limitation), got line %d", v.Line)
+			// The violation points at the out-of-bounds operand q[3]
+			if v.Line != 6 {
+				t.Errorf("Expected violation on line 6, got line %d", v.Line)
 			}
-			if v.Column != 1 {
-				t.Errorf("Expected violation at column 1 (parser limitation), got column %d", v.Column)
+			if v.Column != 9 {
+				t.Errorf("Expected violation at column 9, got column %d", v.Column)
 			}
 			if v.Severity != SeverityError {
 				t.Errorf("Expected severity Error, got %s", v.Severity)
@@ -52,3 +51,51 @@ measure q->c;`
 		t.Error("Expected QAS004 violation, but none was found")
 	}
 }
+
+func TestLintContentViolationModel(t *testing.T) {
+	code := `OPENQASM 3.0;
+include "stdgates.inc";
+qubit q;
+int[32] unused_count = 0;
+creg c[1];
+h q;
+c[0] = measure q;`
+
+	linter := NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	violations, err := linter.LintContent(code, "model.qasm")
+	if err != nil {
+		t.Fatalf("Failed to lint content: %v", err)
+	}
+
+	byRule := make(map[string]*Violation)
+	for _, v := range violations {
+		byRule[v.Rule.ID] = v
+	}
+
+	unused := byRule["QAS020"]
+	if unused == nil {
+		t.Fatal("Expected a QAS020 violation")
+	}
+	// AST rule violations carry the full rule metadata, extent and tags
+	if unused.Rule.Name != "unused-declaration" || unused.Rule.DocumentationURL == "" {
+		t.Errorf("Expected full rule metadata, got %+v", unused.Rule)
+	}
+	if unused.Line != 4 || unused.Column != 1 || unused.EndLine != 4 || unused.EndColumn != 25 {
+		t.Errorf("Expected extent 4:1-4:25, got %d:%d-%d:%d", unused.Line, unused.Column, unused.EndLine, unused.EndColumn)
+	}
+	if !unused.HasTag(TagUnnecessary) {
+		t.Errorf("Expected QAS020 violation to be tagged %q, got %v", TagUnnecessary, unused.Tags)
+	}
+
+	deprecated := byRule["QAS021"]
+	if deprecated == nil {
+		t.Fatal("Expected a QAS021 violation")
+	}
+	if !deprecated.HasTag(TagDeprecated) {
+		t.Errorf("Expected QAS021 violation to be tagged %q, got %v", TagDeprecated, deprecated.Tags)
+	}
+}
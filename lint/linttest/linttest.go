@@ -0,0 +1,315 @@
+// Package linttest runs lint rules against .qasm fixtures annotated with the
+// diagnostics they are expected to produce.
+//
+// A fixture marks each expected diagnostic with a want comment at the end of
+// the line it is reported on, naming the rule and, optionally, a quoted
+// substring of its message:
+//
+//	qubit[2] q;
+//	h q[2]; // want QAS004 "Index out of bounds"
+//	cx q[0], q[3]; // want QAS004 QAS004
+//
+// Run lints each fixture with the built-in and registered rules and fails the
+// test for every diagnostic of the rule under test that no want comment
+// expects, and for every want comment no diagnostic matches. When a fixture
+// such as no_reset.qasm has a golden file no_reset.fixed.qasm next to it, the
+// rule's fixes applied to the fixture must produce the golden file. Rules added
+// with lint.RegisterRule are tested the same way:
+//
+//	func TestNoReset(t *testing.T) {
+//		linttest.Run(t, "ACME001", "testdata/no_reset.qasm")
+//	}
+//
+// RunProject lints the files of a directory together, as project rules such
+// as QAS028 need, and checks the want comments of every file, including .inc
+// files. RunDir tests a directory with one subdirectory of fixtures per rule.
+package linttest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/orangekame3/qasmtools/lint"
+)
+
+// fixedSuffix is the extension of golden files holding a fixture after fixes
+const fixedSuffix = ".fixed.qasm"
+
+// wantComment matches a want comment and captures its expectations
+var wantComment = regexp.MustCompile(`//\s*want\b(.*)$`)
+
+// wantTerm matches one expectation of a want comment: a rule ID optionally
+// followed by a quoted message substring
+var wantTerm = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9_-]*)(?:\s+("(?:[^"\\]|\\.)*"))?`)
+
+// expectation is a diagnostic a fixture expects
+type expectation struct {
+	line    int
+	ruleID  string
+	message string // substring of the message, "" matches any message
+}
+
+// Run lints each fixture and compares the diagnostics of ruleID with the
+// fixture's want comments
+func Run(t *testing.T, ruleID string, fixtures ...string) {
+	t.Helper()
+
+	linter := lint.NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("failed to load rules: %v", err)
+	}
+
+	for _, fixture := range fixtures {
+		content, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+
+		expectations, err := parseExpectations(string(content))
+		if err != nil {
+			t.Errorf("%s: %v", fixture, err)
+			continue
+		}
+
+		violations, err := linter.LintContent(string(content), fixture)
+		if err != nil {
+			t.Errorf("%s: %v", fixture, err)
+			continue
+		}
+
+		for _, problem := range compare(fixture, ruleID, violations, expectations) {
+			t.Error(problem)
+		}
+		checkFixes(t, fixture, string(content), ruleID, violations)
+	}
+}
+
+// RunProject lints the .qasm and .inc files of a directory together and
+// compares the diagnostics of ruleID in each file with its want comments
+func RunProject(t *testing.T, ruleID, dir string) {
+	t.Helper()
+
+	var files []string
+	for _, pattern := range []string{"*.qasm", "*.inc"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			t.Fatalf("failed to list fixtures: %v", err)
+		}
+		for _, match := range matches {
+			if !strings.HasSuffix(match, fixedSuffix) {
+				files = append(files, match)
+			}
+		}
+	}
+	if len(files) == 0 {
+		t.Fatalf("no fixtures in %s", dir)
+	}
+
+	linter := lint.NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatalf("failed to load rules: %v", err)
+	}
+	violations, err := linter.LintFiles(files)
+	if err != nil {
+		t.Fatalf("%s: %v", dir, err)
+	}
+
+	byFile := make(map[string][]*lint.Violation)
+	for _, v := range violations {
+		byFile[v.File] = append(byFile[v.File], v)
+	}
+	for file := range byFile {
+		if !slices.Contains(files, file) {
+			t.Errorf("%s: diagnostic reported in a file outside the project", file)
+		}
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		expectations, err := parseExpectations(string(content))
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+			continue
+		}
+		for _, problem := range compare(file, ruleID, byFile[file], expectations) {
+			t.Error(problem)
+		}
+	}
+}
+
+// RunDir runs the fixtures of a directory laid out by rule ID, such as
+//
+//	testdata/lint/QAS004/out_of_bounds.qasm
+//	testdata/lint/QAS020/unused.qasm
+//	testdata/lint/QAS020/unused.fixed.qasm
+//	testdata/lint/QAS028/library/main.qasm
+//	testdata/lint/QAS028/library/gates.inc
+//
+// Each subdirectory runs as a subtest named after its rule. Fixtures directly
+// in it are linted one at a time, and each directory below it is linted as a
+// project with RunProject.
+func RunDir(t *testing.T, dir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read fixtures: %v", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		ruleID := entry.Name()
+
+		fixtures, err := filepath.Glob(filepath.Join(dir, ruleID, "*.qasm"))
+		if err != nil {
+			t.Fatalf("failed to list fixtures: %v", err)
+		}
+		var inputs []string
+		for _, fixture := range fixtures {
+			if !strings.HasSuffix(fixture, fixedSuffix) {
+				inputs = append(inputs, fixture)
+			}
+		}
+
+		subdirs, err := os.ReadDir(filepath.Join(dir, ruleID))
+		if err != nil {
+			t.Fatalf("failed to read fixtures: %v", err)
+		}
+		var projects []string
+		for _, subdir := range subdirs {
+			if subdir.IsDir() {
+				projects = append(projects, filepath.Join(dir, ruleID, subdir.Name()))
+			}
+		}
+
+		t.Run(ruleID, func(t *testing.T) {
+			if len(inputs) == 0 && len(projects) == 0 {
+				t.Fatalf("no fixtures in %s", filepath.Join(dir, ruleID))
+			}
+			if len(inputs) > 0 {
+				Run(t, ruleID, inputs...)
+			}
+			for _, project := range projects {
+				t.Run(filepath.Base(project), func(t *testing.T) {
+					RunProject(t, ruleID, project)
+				})
+			}
+		})
+	}
+}
+
+// checkFixes applies the fixes of ruleID to a fixture and compares the result
+// with the fixture's golden file, if it has one
+func checkFixes(t *testing.T, fixture, content, ruleID string, violations []*lint.Violation) {
+	t.Helper()
+
+	golden := strings.TrimSuffix(fixture, ".qasm") + fixedSuffix
+	expected, err := os.ReadFile(golden)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		t.Errorf("%v", err)
+		return
+	}
+
+	var ruleViolations []*lint.Violation
+	for _, v := range violations {
+		if v.Rule != nil && v.Rule.ID == ruleID {
+			ruleViolations = append(ruleViolations, v)
+		}
+	}
+
+	fixed, _ := lint.ApplyFixes(content, ruleViolations)
+	if fixed != string(expected) {
+		t.Errorf("%s: fixed content does not match %s\n--- got:\n%s\n--- want:\n%s", fixture, golden, fixed, expected)
+	}
+}
+
+// parseExpectations reads the want comments of a fixture
+func parseExpectations(content string) ([]expectation, error) {
+	var expectations []expectation
+
+	for i, line := range strings.Split(content, "\n") {
+		match := wantComment.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		terms := strings.TrimSpace(match[1])
+		if terms == "" {
+			return nil, fmt.Errorf("line %d: want comment without a rule ID", i+1)
+		}
+		for terms != "" {
+			term := wantTerm.FindStringSubmatch(terms)
+			if term == nil {
+				return nil, fmt.Errorf("line %d: malformed want comment near %q", i+1, terms)
+			}
+
+			want := expectation{line: i + 1, ruleID: term[1]}
+			if term[2] != "" {
+				message, err := strconv.Unquote(term[2])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid message %s: %w", i+1, term[2], err)
+				}
+				want.message = message
+			}
+			expectations = append(expectations, want)
+			terms = strings.TrimSpace(terms[len(term[0]):])
+		}
+	}
+
+	return expectations, nil
+}
+
+// compare matches the violations of ruleID with the expectations for that
+// rule and describes every unexpected violation and unmatched expectation
+func compare(fixture, ruleID string, violations []*lint.Violation, expectations []expectation) []string {
+	var problems []string
+	matched := make([]bool, len(expectations))
+
+	for _, v := range violations {
+		if v.Rule == nil || v.Rule.ID != ruleID {
+			continue
+		}
+
+		found := false
+		for i, want := range expectations {
+			if !matched[i] && want.ruleID == ruleID && want.line == v.Line && strings.Contains(v.Message, want.message) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s:%d:%d: unexpected diagnostic: [%s] %s",
+				fixture, v.Line, v.Column, ruleID, v.Message))
+		}
+	}
+
+	for i, want := range expectations {
+		if matched[i] || want.ruleID != ruleID {
+			continue
+		}
+		expected := want.ruleID
+		if want.message != "" {
+			expected += " " + strconv.Quote(want.message)
+		}
+		problems = append(problems, fmt.Sprintf("%s:%d: missing diagnostic: %s", fixture, want.line, expected))
+	}
+
+	return problems
+}
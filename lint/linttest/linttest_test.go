@@ -0,0 +1,68 @@
+package linttest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/orangekame3/qasmtools/lint"
+)
+
+func TestRun(t *testing.T) {
+	Run(t, "QAS004", "testdata/out_of_bounds.qasm")
+}
+
+func TestParseExpectations(t *testing.T) {
+	content := `qubit q;
+x q; // want QAS001
+h q; // want QAS004 "out of \"bounds\"" QAS002
+// wanted: not a want comment`
+
+	expectations, err := parseExpectations(content)
+	if err != nil {
+		t.Fatalf("parseExpectations() error = %v", err)
+	}
+
+	expected := []expectation{
+		{line: 2, ruleID: "QAS001"},
+		{line: 3, ruleID: "QAS004", message: `out of "bounds"`},
+		{line: 3, ruleID: "QAS002"},
+	}
+	if !reflect.DeepEqual(expectations, expected) {
+		t.Errorf("parseExpectations() = %+v, want %+v", expectations, expected)
+	}
+}
+
+func TestParseExpectationsInvalid(t *testing.T) {
+	for _, content := range []string{
+		"x q; // want",
+		`x q; // want "message without rule"`,
+		`x q; // want QAS001 "unterminated`,
+	} {
+		if _, err := parseExpectations(content); err == nil {
+			t.Errorf("parseExpectations(%q) expected an error", content)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	rule := &lint.Rule{ID: "QAS004"}
+	violations := []*lint.Violation{
+		{Rule: rule, Line: 2, Column: 3, Message: "Index out of bounds"},
+		{Rule: rule, Line: 5, Column: 1, Message: "Index out of bounds"},
+		{Rule: &lint.Rule{ID: "QAS001"}, Line: 7, Message: "ignored"},
+	}
+	expectations := []expectation{
+		{line: 2, ruleID: "QAS004", message: "out of bounds"},
+		{line: 3, ruleID: "QAS004"},
+		{line: 9, ruleID: "QAS001"},
+	}
+
+	problems := compare("f.qasm", "QAS004", violations, expectations)
+	expected := []string{
+		"f.qasm:5:1: unexpected diagnostic: [QAS004] Index out of bounds",
+		"f.qasm:3: missing diagnostic: QAS004",
+	}
+	if !reflect.DeepEqual(problems, expected) {
+		t.Errorf("compare() = %q, want %q", problems, expected)
+	}
+}
@@ -0,0 +1,12 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q;
+bit[2] c;
+
+h q[0];
+h q[2]; // want QAS004 "accessing '2' on 'q'"
+cx q[0], q[1];
+cx q[3], q[4]; // want QAS004 QAS004
+c[0] = measure q[0];
+c[1] = measure q[1];
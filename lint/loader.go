@@ -31,15 +31,30 @@ func NewRuleLoader(rulesDir string) *RuleLoader {
 	}
 }
 
-// LoadRules loads all rules from the rules directory
+// LoadRules loads the enabled rules from the rules directory, followed by the
+// registered rules that the directory does not define
 func (l *RuleLoader) LoadRules() ([]*Rule, error) {
+	var rules []*Rule
+	var err error
 	if l.useEmbedded {
-		return l.loadEmbeddedRules()
+		rules, err = l.loadEmbeddedRules()
+	} else {
+		rules, err = l.loadFileSystemRules()
+	}
+	if err != nil {
+		return nil, err
 	}
-	return l.loadFileSystemRules()
+
+	var enabled []*Rule
+	for _, rule := range appendRegisteredRules(rules) {
+		if rule.Enabled {
+			enabled = append(enabled, rule)
+		}
+	}
+	return enabled, nil
 }
 
-// loadEmbeddedRules loads rules from embedded files
+// loadEmbeddedRules loads all rules from embedded files, including disabled ones
 func (l *RuleLoader) loadEmbeddedRules() ([]*Rule, error) {
 	var rules []*Rule
 
@@ -62,10 +77,7 @@ func (l *RuleLoader) loadEmbeddedRules() ([]*Rule, error) {
 			return fmt.Errorf("failed to parse embedded rule %s: %w", path, err)
 		}
 
-		if rule.Enabled {
-			rules = append(rules, rule)
-		}
-
+		rules = append(rules, rule)
 		return nil
 	})
 
@@ -76,7 +88,7 @@ func (l *RuleLoader) loadEmbeddedRules() ([]*Rule, error) {
 	return rules, nil
 }
 
-// loadFileSystemRules loads rules from filesystem
+// loadFileSystemRules loads all rules from filesystem, including disabled ones
 func (l *RuleLoader) loadFileSystemRules() ([]*Rule, error) {
 	var rules []*Rule
 
@@ -94,10 +106,7 @@ func (l *RuleLoader) loadFileSystemRules() ([]*Rule, error) {
 			return fmt.Errorf("failed to load rule from %s: %w", path, err)
 		}
 
-		if rule.Enabled {
-			rules = append(rules, rule)
-		}
-
+		rules = append(rules, rule)
 		return nil
 	})
 
@@ -126,15 +135,24 @@ func (l *RuleLoader) parseRuleYAML(data []byte) (*Rule, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	if err := validateRule(&rule); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// validateRule checks the required fields of a rule and sets defaults
+func validateRule(rule *Rule) error {
 	// Validate required fields
 	if rule.ID == "" {
-		return nil, fmt.Errorf("rule ID is required")
+		return fmt.Errorf("rule ID is required")
 	}
 	if rule.Name == "" {
-		return nil, fmt.Errorf("rule name is required")
+		return fmt.Errorf("rule name is required")
 	}
 	if rule.Message == "" {
-		return nil, fmt.Errorf("rule message is required")
+		return fmt.Errorf("rule message is required")
 	}
 
 	// Set defaults
@@ -142,7 +160,7 @@ func (l *RuleLoader) parseRuleYAML(data []byte) (*Rule, error) {
 		rule.Level = SeverityWarning
 	}
 
-	return &rule, nil
+	return nil
 }
 
 // LoadRule loads a specific rule by ID
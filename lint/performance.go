@@ -50,6 +50,13 @@ func (l *LinterWithMetrics) ResetStats() {
 
 // LintFileWithMetrics lints a file with performance tracking
 func (l *LinterWithMetrics) LintFileWithMetrics(filename string) ([]*Violation, error) {
+	violations, _, err := l.lintFileWithMetrics(filename, l.cacheFingerprint())
+	return violations, err
+}
+
+// lintFileWithMetrics lints a file with performance tracking and returns its
+// parsed program, which is nil when the result comes from the cache
+func (l *LinterWithMetrics) lintFileWithMetrics(filename, fingerprint string) ([]*Violation, *parser.Program, error) {
 	start := time.Now()
 	defer func() {
 		l.mutex.Lock()
@@ -58,16 +65,16 @@ func (l *LinterWithMetrics) LintFileWithMetrics(filename string) ([]*Violation,
 		l.mutex.Unlock()
 	}()
 
-	violations, err := l.LintFile(filename)
+	violations, program, err := l.lintFile(filename, fingerprint)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	l.mutex.Lock()
 	l.stats.ViolationsFound += len(violations)
 	l.mutex.Unlock()
 
-	return violations, nil
+	return violations, program, nil
 }
 
 // BatchLinter provides optimized batch linting capabilities
@@ -87,7 +94,8 @@ func NewBatchLinter(rulesDir string, concurrency int) *BatchLinter {
 	}
 }
 
-// LintFilesParallel lints multiple files in parallel
+// LintFilesParallel lints multiple files in parallel, followed by the project
+// rules checking them together
 func (l *BatchLinter) LintFilesParallel(filenames []string) ([]*Violation, error) {
 	// Load rules once
 	if err := l.LoadRules(); err != nil {
@@ -95,19 +103,22 @@ func (l *BatchLinter) LintFilesParallel(filenames []string) ([]*Violation, error
 	}
 
 	type result struct {
+		filename   string
 		violations []*Violation
+		program    *parser.Program
 		err        error
 	}
 
 	jobs := make(chan string, len(filenames))
 	results := make(chan result, len(filenames))
+	fingerprint := l.cacheFingerprint()
 
 	// Start workers
 	for i := 0; i < l.concurrency; i++ {
 		go func() {
 			for filename := range jobs {
-				violations, err := l.LintFileWithMetrics(filename)
-				results <- result{violations: violations, err: err}
+				violations, program, err := l.lintFileWithMetrics(filename, fingerprint)
+				results <- result{filename: filename, violations: violations, program: program, err: err}
 			}
 		}()
 	}
@@ -121,16 +132,29 @@ func (l *BatchLinter) LintFilesParallel(filenames []string) ([]*Violation, error
 	// Collect results
 	var allViolations []*Violation
 	var firstError error
+	programs := make(map[string]*parser.Program)
 
 	for i := 0; i < len(filenames); i++ {
 		res := <-results
 		if res.err != nil && firstError == nil {
 			firstError = res.err
 		}
+		if res.program != nil {
+			programs[projectKey(res.filename)] = res.program
+		}
 		allViolations = append(allViolations, res.violations...)
 	}
+	if firstError != nil {
+		return allViolations, firstError
+	}
+
+	// Project rules check the files together once every file is linted
+	projectViolations, err := l.lintProject(filenames, programs)
+	if err != nil {
+		return allViolations, err
+	}
 
-	return allViolations, firstError
+	return append(allViolations, projectViolations...), nil
 }
 
 // OptimizedLinter provides additional optimizations for AST-based linting
@@ -180,6 +204,7 @@ func (l *OptimizedLinter) LintFileOptimized(filename string, content string) ([]
 		Content:  content,
 		Program:  program,
 		UsageMap: usageMap,
+		Target:   l.target,
 	}
 
 	var allViolations []*Violation
@@ -206,16 +231,14 @@ func (l *OptimizedLinter) LintFileOptimized(filename string, content string) ([]
 			}
 
 			if astRule != nil {
-				astCtx := l.convertToASTContext(context)
-				astViolations := astRule.CheckAST(program, astCtx)
-				violations = l.convertASTViolations(astViolations)
+				violations = astRule.CheckAST(program, l.ruleContext(context, rule))
 			}
 		}
 
 		// Set rule reference for each violation
 		for _, violation := range violations {
 			violation.Rule = rule
-			violation.Severity = rule.Level
+			violation.Severity = violation.Severity.AtMost(rule.Level)
 		}
 
 		allViolations = append(allViolations, violations...)
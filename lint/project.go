@@ -0,0 +1,224 @@
+package lint
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/orangekame3/qasmtools/lint/ast"
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// LoadProject summarizes the given files and, recursively, the files they
+// include for the project rules. Include paths are resolved relative to the
+// including file. Well-known include files and files that cannot be read or
+// parsed are left unresolved.
+func LoadProject(files []string) (*Project, error) {
+	return newProjectLoader(nil, nil).load(files)
+}
+
+// projectLoader builds a project, indexing its files by absolute path
+type projectLoader struct {
+	project *Project
+	// files holds the loaded files by absolute path, with nil for files
+	// that could not be read or parsed
+	files map[string]*ProjectFile
+	// programs holds the programs already parsed while linting the files
+	// one by one, by absolute path
+	programs map[string]*parser.Program
+	// cache holds the summaries of earlier runs, nil when disabled
+	cache *Cache
+}
+
+// newProjectLoader creates a loader reusing parsed programs and cached summaries
+func newProjectLoader(programs map[string]*parser.Program, cache *Cache) *projectLoader {
+	return &projectLoader{
+		project:  &Project{},
+		files:    make(map[string]*ProjectFile),
+		programs: programs,
+		cache:    cache,
+	}
+}
+
+// load loads the given files together with the files they include
+func (l *projectLoader) load(files []string) (*Project, error) {
+	// Add every linted file first, so that includes of linted files resolve
+	// to them and keep the path they were given with
+	for _, path := range files {
+		if _, exists := l.files[projectKey(path)]; exists {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+		file, err := l.summarize(path, string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file %s: %w", path, err)
+		}
+		file.Linted = true
+		l.add(path, file)
+	}
+
+	// Files reached through includes are appended while resolving
+	for i := 0; i < len(l.project.Files); i++ {
+		l.resolveIncludes(l.project.Files[i])
+	}
+
+	return l.project, nil
+}
+
+// summarize returns the summary of a file, reusing its parsed program or a
+// cached summary of the same content before parsing it
+func (l *projectLoader) summarize(path, content string) (*ProjectFile, error) {
+	program, parsed := l.programs[projectKey(path)]
+	if !parsed && l.cache != nil {
+		if file, ok := l.cache.lookupProjectFile(content); ok {
+			return file, nil
+		}
+	}
+
+	if !parsed {
+		result := parseSource(content)
+		if err := syntaxError(result); err != nil {
+			return nil, err
+		}
+		if result.Program == nil {
+			return nil, fmt.Errorf("program is nil")
+		}
+		program = result.Program
+	}
+
+	file := summarizeProgram(program)
+	if l.cache != nil {
+		l.cache.storeProjectFile(content, file)
+	}
+	return file, nil
+}
+
+// add adds a summarized file to the project
+func (l *projectLoader) add(path string, file *ProjectFile) *ProjectFile {
+	file.Path = path
+	l.files[projectKey(path)] = file
+	l.project.Files = append(l.project.Files, file)
+	return file
+}
+
+// resolveIncludes resolves the include statements of a file, loading the
+// included files that are not part of the project yet
+func (l *projectLoader) resolveIncludes(file *ProjectFile) {
+	for _, include := range file.Includes {
+		if astutil.StandardIncludes[include.Path] {
+			continue
+		}
+
+		path := filepath.Join(filepath.Dir(file.Path), include.Path)
+		if included, loaded := l.files[projectKey(path)]; loaded {
+			include.File = included
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			l.files[projectKey(path)] = nil
+			continue
+		}
+		included, err := l.summarize(path, string(content))
+		if err != nil {
+			l.files[projectKey(path)] = nil
+			continue
+		}
+		include.File = l.add(path, included)
+	}
+}
+
+// summarizeProgram collects the global gate definitions, the gates called
+// outside their own definition and the include statements of a program
+func summarizeProgram(program *parser.Program) *ProjectFile {
+	file := &ProjectFile{}
+	called := make(map[string]bool)
+
+	for _, stmt := range program.Statements {
+		switch s := stmt.(type) {
+		case *parser.Include:
+			file.Includes = append(file.Includes, &ProjectInclude{BaseNode: s.BaseNode, Path: s.Path})
+		case *parser.GateDefinition:
+			file.Gates = append(file.Gates, &ProjectGate{
+				BaseNode:   s.BaseNode,
+				Name:       s.Name,
+				Parameters: len(s.Parameters),
+				Qubits:     len(s.Qubits),
+				Includes:   len(file.Includes),
+			})
+		}
+
+		// Recursive calls from inside a definition do not count as uses
+		gateDef, _ := stmt.(*parser.GateDefinition)
+		astutil.VisitAllNodes(stmt, func(node parser.Node) {
+			if call, ok := node.(*parser.GateCall); ok && (gateDef == nil || call.Name != gateDef.Name) {
+				called[call.Name] = true
+			}
+		})
+	}
+
+	file.Calls = slices.Sorted(maps.Keys(called))
+	return file
+}
+
+// projectKey identifies a file by its absolute path
+func projectKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// LintProject runs the project rules on the given files together with the
+// files they include. Files are only read when a project rule is enabled.
+func (l *Linter) LintProject(files []string) ([]*Violation, error) {
+	return l.lintProject(files, nil)
+}
+
+// lintProject runs the project rules, reusing the programs parsed while
+// linting the files one by one and the summaries cached by earlier runs
+func (l *Linter) lintProject(files []string, programs map[string]*parser.Program) ([]*Violation, error) {
+	if !l.useAST {
+		return nil, nil
+	}
+
+	var rules []*Rule
+	for _, rule := range l.rules {
+		if _, ok := l.astRules[rule.ID].(ast.ProjectRule); ok && rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	project, err := newProjectLoader(programs, l.cache).load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	context := &CheckContext{Target: l.target}
+
+	var allViolations []*Violation
+	for _, rule := range rules {
+		projectRule := l.astRules[rule.ID].(ast.ProjectRule)
+		violations := projectRule.CheckProject(project, l.ruleContext(context, rule))
+
+		// Set rule reference for each violation
+		for _, violation := range violations {
+			violation.Rule = rule
+			violation.Severity = violation.Severity.AtMost(rule.Level)
+		}
+
+		allViolations = append(allViolations, violations...)
+	}
+
+	return allViolations, nil
+}
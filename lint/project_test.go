@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadProject(t *testing.T) {
+	dir := t.TempDir()
+	main := writeFile(t, dir, "main.qasm", `OPENQASM 3.0;
+include "stdgates.inc";
+include "lib/gates.inc";
+include "missing.inc";
+qubit[2] q;
+bell q[0], q[1];
+`)
+	if err := os.Mkdir(filepath.Join(dir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gates := writeFile(t, dir, "lib/gates.inc", `include "stdgates.inc";
+include "helpers.inc";
+gate bell a, b { h a; cx a, b; }
+`)
+	writeFile(t, dir, "lib/helpers.inc", "gate flip a { x a; }\n")
+
+	project, err := LoadProject([]string{main, gates})
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+
+	if len(project.Files) != 3 {
+		t.Fatalf("got %d files, want 3", len(project.Files))
+	}
+	mainFile, gatesFile, helpersFile := project.Files[0], project.Files[1], project.Files[2]
+	if !mainFile.Linted || !gatesFile.Linted || helpersFile.Linted {
+		t.Errorf("linted = %v, %v, %v, want true, true, false", mainFile.Linted, gatesFile.Linted, helpersFile.Linted)
+	}
+	if helpersFile.Path != filepath.Join(dir, "lib", "helpers.inc") {
+		t.Errorf("included file path = %s", helpersFile.Path)
+	}
+
+	// stdgates.inc and missing files stay unresolved
+	if len(mainFile.Includes) != 3 {
+		t.Fatalf("got %d includes, want 3", len(mainFile.Includes))
+	}
+	if mainFile.Includes[0].File != nil || mainFile.Includes[1].File != gatesFile || mainFile.Includes[2].File != nil {
+		t.Errorf("includes of main.qasm resolved to %v", mainFile.Includes)
+	}
+	if gatesFile.Includes[1].File != helpersFile {
+		t.Errorf("helpers.inc not resolved from lib/gates.inc")
+	}
+
+	if includers := project.Includers(gatesFile); len(includers) != 1 || includers[0] != mainFile {
+		t.Errorf("Includers(gates.inc) = %v, want main.qasm", includers)
+	}
+	if includers := project.Includers(mainFile); len(includers) != 0 {
+		t.Errorf("Includers(main.qasm) = %v, want none", includers)
+	}
+}
+
+func TestProjectRulesOnlyRunOnProjects(t *testing.T) {
+	dir := t.TempDir()
+	main := writeFile(t, dir, "main.qasm", `OPENQASM 3.0;
+include "stdgates.inc";
+include "gates.inc";
+qubit[2] q;
+bell q[0], q[1];
+`)
+	gates := writeFile(t, dir, "gates.inc", `include "stdgates.inc";
+gate bell a, b { h a; cx a, b; }
+gate unused a { x a; }
+`)
+
+	linter := NewLinter("")
+	if err := linter.LoadRules(); err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := linter.LintFile(gates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countRule(violations, "QAS028") != 0 {
+		t.Errorf("LintFile reported QAS028 for a single file")
+	}
+
+	violations, err = linter.LintFiles([]string{main, gates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countRule(violations, "QAS028"); got != 1 {
+		t.Errorf("LintFiles reported %d QAS028 violations, want 1", got)
+	}
+
+	batch := NewBatchLinter("", 2)
+	violations, err = batch.LintFilesParallel([]string{main, gates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countRule(violations, "QAS028"); got != 1 {
+		t.Errorf("LintFilesParallel reported %d QAS028 violations, want 1", got)
+	}
+}
+
+// countRule counts the violations of a rule
+func countRule(violations []*Violation, ruleID string) int {
+	count := 0
+	for _, v := range violations {
+		if v.Rule.ID == ruleID {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/orangekame3/qasmtools/lint/ast"
+)
+
+// RuleFactory creates a new instance of an AST-based rule
+type RuleFactory func() ast.ASTRule
+
+// registeredRule is a rule added through RegisterRule
+type registeredRule struct {
+	meta    *Rule
+	factory RuleFactory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]registeredRule)
+)
+
+// RegisterRule makes a third-party rule available to every linter in the
+// process, typically from the init function of the package defining it:
+//
+//	func init() {
+//		lint.RegisterRule(&lint.Rule{
+//			ID:      "ACME001",
+//			Name:    "no-reset",
+//			Level:   lint.SeverityWarning,
+//			Enabled: true,
+//			Message: "Reset is not supported on our devices.",
+//		}, NewNoResetRule)
+//	}
+//
+// meta plays the role of a rule's YAML file: a rule is only run when
+// meta.Enabled is set, and a YAML file with the same ID in the rules directory
+// takes precedence over it. RegisterRule panics when meta is incomplete, the
+// factory is nil or the ID is already taken by a built-in or registered rule.
+func RegisterRule(meta *Rule, factory RuleFactory) {
+	if meta == nil || factory == nil {
+		panic("lint: RegisterRule called with nil rule or factory")
+	}
+
+	rule := cloneRule(meta)
+	if err := validateRule(rule); err != nil {
+		panic(fmt.Sprintf("lint: RegisterRule %s: %v", meta.ID, err))
+	}
+	if createBuiltinRule(rule.ID) != nil {
+		panic(fmt.Sprintf("lint: RegisterRule %s: ID is used by a built-in rule", rule.ID))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[rule.ID]; exists {
+		panic(fmt.Sprintf("lint: RegisterRule %s: rule is already registered", rule.ID))
+	}
+	registry[rule.ID] = registeredRule{meta: rule, factory: factory}
+}
+
+// createRegisteredRule creates a registered rule, returning nil when no rule
+// is registered under the ID
+func createRegisteredRule(ruleID string) ast.ASTRule {
+	registryMu.RLock()
+	registered, exists := registry[ruleID]
+	registryMu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+	return registered.factory()
+}
+
+// appendRegisteredRules adds the metadata of registered rules that are not
+// defined in rules, ordered by ID
+func appendRegisteredRules(rules []*Rule) []*Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		meta := registry[id].meta
+		defined := slices.ContainsFunc(rules, func(rule *Rule) bool { return rule.ID == id })
+		if !defined {
+			rules = append(rules, cloneRule(meta))
+		}
+	}
+	return rules
+}
+
+// cloneRule copies a rule so that linters can change the copy's settings
+func cloneRule(rule *Rule) *Rule {
+	clone := *rule
+	clone.Check = slices.Clone(rule.Check)
+	clone.Tags = slices.Clone(rule.Tags)
+	return &clone
+}
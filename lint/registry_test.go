@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/orangekame3/qasmtools/lint/ast"
+	"github.com/orangekame3/qasmtools/lint/astutil"
+	"github.com/orangekame3/qasmtools/parser"
+)
+
+// forbiddenGateRule is a third-party style rule reporting calls to a gate
+// named forbidden
+type forbiddenGateRule struct {
+	*ast.ASTRuleBase
+}
+
+func (r *forbiddenGateRule) CheckAST(program *parser.Program, ctx *CheckContext) []*Violation {
+	var violations []*Violation
+	for _, call := range astutil.FindNodesByType(program, (*parser.GateCall)(nil)) {
+		if call.Name == "forbidden" {
+			violations = append(violations, r.NewViolationBuilder().
+				WithMessage("Gate 'forbidden' must not be used.").
+				WithFile(ctx.File).
+				WithNode(call).
+				AsWarning().
+				Build())
+		}
+	}
+	return violations
+}
+
+func init() {
+	RegisterRule(&Rule{
+		ID:      "TST001",
+		Name:    "forbidden-gate",
+		Level:   SeverityWarning,
+		Enabled: true,
+		Message: "Gate 'forbidden' must not be used.",
+	}, func() ast.ASTRule {
+		return &forbiddenGateRule{ASTRuleBase: ast.NewASTRuleBase("TST001")}
+	})
+}
+
+func TestRegisterRule(t *testing.T) {
+	violations := lintRule(t, "OPENQASM 3.0;\nqubit q;\nforbidden q;", "TST001")
+	if len(violations) != 1 {
+		t.Fatalf("Expected 1 TST001 violation, got %d", len(violations))
+	}
+	if v := violations[0]; v.Line != 3 || v.Rule.Name != "forbidden-gate" {
+		t.Errorf("Expected forbidden-gate violation on line 3, got %s", v.String())
+	}
+
+	if CreateASTRule("TST001") == nil {
+		t.Error("Expected CreateASTRule to create the registered rule")
+	}
+}
+
+func TestRegisterRuleOverriddenByRulesDirectory(t *testing.T) {
+	dir := t.TempDir()
+	yaml := "id: TST001\nname: forbidden-gate\nlevel: error\nenabled: false\nmessage: Disabled.\n"
+	if err := os.WriteFile(filepath.Join(dir, "TST001.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := NewRuleLoader(dir).LoadRules()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	for _, rule := range rules {
+		if rule.ID == "TST001" {
+			t.Errorf("Expected TST001 to be disabled by the rules directory")
+		}
+	}
+}
+
+func TestRegisterRulePanics(t *testing.T) {
+	factory := func() ast.ASTRule { return nil }
+	tests := []struct {
+		name    string
+		meta    *Rule
+		factory RuleFactory
+	}{
+		{"nil factory", &Rule{ID: "TST002", Name: "n", Message: "m"}, nil},
+		{"missing name", &Rule{ID: "TST002", Message: "m"}, factory},
+		{"built-in ID", &Rule{ID: "QAS001", Name: "n", Message: "m"}, factory},
+		{"already registered", &Rule{ID: "TST001", Name: "n", Message: "m"}, factory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected RegisterRule to panic")
+				}
+			}()
+			RegisterRule(tt.meta, tt.factory)
+		})
+	}
+}
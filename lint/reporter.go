@@ -0,0 +1,249 @@
+package lint
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Reporter writes lint violations to an output stream in a specific format
+type Reporter interface {
+	Report(w io.Writer, violations []*Violation) error
+}
+
+// ReporterFactory creates a new reporter instance
+type ReporterFactory func() Reporter
+
+// reporters holds the built-in reporters keyed by format name
+var reporters = map[string]ReporterFactory{
+	"json":       func() Reporter { return &JSONReporter{} },
+	"checkstyle": func() Reporter { return &CheckstyleReporter{} },
+	"junit":      func() Reporter { return &JUnitReporter{} },
+	"github":     func() Reporter { return &GitHubReporter{} },
+}
+
+// NewReporter creates a reporter for the given output format
+func NewReporter(format string) (Reporter, error) {
+	factory, exists := reporters[format]
+	if !exists {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)", format, strings.Join(ReporterFormats(), ", "))
+	}
+	return factory(), nil
+}
+
+// RegisterReporter registers a reporter for the given output format
+func RegisterReporter(format string, factory ReporterFactory) {
+	reporters[format] = factory
+}
+
+// ReporterFormats returns the names of all registered output formats
+func ReporterFormats() []string {
+	formats := make([]string, 0, len(reporters))
+	for format := range reporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// JSONReporter outputs violations as an indented JSON array
+type JSONReporter struct{}
+
+// Report writes violations in JSON format
+func (r *JSONReporter) Report(w io.Writer, violations []*Violation) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(violations)
+}
+
+// CheckstyleReporter outputs violations in Checkstyle XML format
+type CheckstyleReporter struct{}
+
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Report writes violations in Checkstyle XML format
+func (r *CheckstyleReporter) Report(w io.Writer, violations []*Violation) error {
+	result := checkstyleResult{Version: "4.3"}
+
+	for _, group := range groupViolationsByFile(violations) {
+		file := checkstyleFile{Name: group.file}
+		for _, v := range group.violations {
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     v.Line,
+				Column:   v.Column,
+				Severity: string(v.Severity),
+				Message:  v.Message,
+				Source:   "qasm-lint." + v.Rule.ID,
+			})
+		}
+		result.Files = append(result.Files, file)
+	}
+
+	return writeXML(w, result)
+}
+
+// JUnitReporter outputs violations in JUnit XML format, one test suite per file
+type JUnitReporter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Report writes violations in JUnit XML format
+func (r *JUnitReporter) Report(w io.Writer, violations []*Violation) error {
+	result := junitTestSuites{Name: "qasm-lint"}
+
+	for _, group := range groupViolationsByFile(violations) {
+		suite := junitTestSuite{Name: group.file}
+		for _, v := range group.violations {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      fmt.Sprintf("%s:%d:%d", v.File, v.Line, v.Column),
+				ClassName: v.Rule.ID,
+				Failure: &junitFailure{
+					Message: v.Message,
+					Type:    string(v.Severity),
+					Text:    v.String(),
+				},
+			})
+		}
+		suite.Tests = len(suite.Cases)
+		suite.Failures = len(suite.Cases)
+		result.Suites = append(result.Suites, suite)
+
+		result.Tests += suite.Tests
+		result.Failures += suite.Failures
+	}
+
+	return writeXML(w, result)
+}
+
+// GitHubReporter outputs violations as GitHub Actions workflow commands
+type GitHubReporter struct{}
+
+// Report writes one annotation command per violation
+func (r *GitHubReporter) Report(w io.Writer, violations []*Violation) error {
+	for _, v := range violations {
+		_, err := fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d,title=%s::%s\n",
+			githubAnnotationLevel(v.Severity),
+			escapeGitHubProperty(v.File),
+			v.Line,
+			v.Column,
+			escapeGitHubProperty(v.Rule.ID),
+			escapeGitHubData(v.Message))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubAnnotationLevel maps a severity to a GitHub Actions annotation command
+func githubAnnotationLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// escapeGitHubData escapes the message part of a workflow command
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	s = strings.ReplaceAll(s, ",", "%2C")
+	return s
+}
+
+// fileViolations holds the violations reported for a single file
+type fileViolations struct {
+	file       string
+	violations []*Violation
+}
+
+// groupViolationsByFile groups violations by file, preserving first-seen order
+func groupViolationsByFile(violations []*Violation) []*fileViolations {
+	var groups []*fileViolations
+	index := make(map[string]*fileViolations)
+
+	for _, v := range violations {
+		group, exists := index[v.File]
+		if !exists {
+			group = &fileViolations{file: v.File}
+			index[v.File] = group
+			groups = append(groups, group)
+		}
+		group.violations = append(group.violations, v)
+	}
+
+	return groups
+}
+
+// writeXML writes an XML document with header and indentation
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
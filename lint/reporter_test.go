@@ -0,0 +1,116 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sampleViolations() []*Violation {
+	return []*Violation{
+		{
+			Rule:     &Rule{ID: "QAS001"},
+			Message:  "Qubit 'unused_q' is declared but never used.",
+			File:     "a.qasm",
+			Line:     4,
+			Column:   1,
+			Severity: SeverityWarning,
+		},
+		{
+			Rule:     &Rule{ID: "QAS004"},
+			Message:  "Index out of bounds: accessing '3' on 'q' of length 2.",
+			File:     "a.qasm",
+			Line:     6,
+			Column:   4,
+			Severity: SeverityError,
+		},
+		{
+			Rule:     &Rule{ID: "QAS005"},
+			Message:  "Identifier 'Bad' should be lowercase, 100% sure",
+			File:     "dir/b,c.qasm",
+			Line:     2,
+			Column:   7,
+			Severity: SeverityInfo,
+		},
+	}
+}
+
+func TestNewReporter(t *testing.T) {
+	for _, format := range []string{"json", "checkstyle", "junit", "github"} {
+		if _, err := NewReporter(format); err != nil {
+			t.Errorf("NewReporter(%q) returned error: %v", format, err)
+		}
+	}
+
+	if _, err := NewReporter("yaml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestCheckstyleReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&CheckstyleReporter{}).Report(&buf, sampleViolations()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var result checkstyleResult
+	if err := xml.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	if len(result.Files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(result.Files))
+	}
+	if len(result.Files[0].Errors) != 2 {
+		t.Errorf("Expected 2 errors for a.qasm, got %d", len(result.Files[0].Errors))
+	}
+	if got := result.Files[0].Errors[1].Source; got != "qasm-lint.QAS004" {
+		t.Errorf("Expected source qasm-lint.QAS004, got %s", got)
+	}
+}
+
+func TestJUnitReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&JUnitReporter{}).Report(&buf, sampleViolations()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	var result junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("Output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	if result.Tests != 3 || result.Failures != 3 {
+		t.Errorf("Expected 3 tests and 3 failures, got %d and %d", result.Tests, result.Failures)
+	}
+	if len(result.Suites) != 2 {
+		t.Fatalf("Expected 2 test suites, got %d", len(result.Suites))
+	}
+	if result.Suites[0].Cases[0].Failure == nil {
+		t.Error("Expected test case to contain a failure")
+	}
+}
+
+func TestGitHubReporter(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&GitHubReporter{}).Report(&buf, sampleViolations()); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		"::warning file=a.qasm,line=4,col=1,title=QAS001::Qubit 'unused_q' is declared but never used.",
+		"::error file=a.qasm,line=6,col=4,title=QAS004::Index out of bounds: accessing '3' on 'q' of length 2.",
+		"::notice file=dir/b%2Cc.qasm,line=2,col=7,title=QAS005::Identifier 'Bad' should be lowercase, 100%25 sure",
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(expected), len(lines), buf.String())
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Line %d:\nexpected %s\ngot      %s", i, expected[i], lines[i])
+		}
+	}
+}
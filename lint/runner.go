@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/orangekame3/qasmtools/discover"
 	"github.com/orangekame3/qasmtools/lint/ast"
 	"github.com/orangekame3/qasmtools/parser"
 )
@@ -17,6 +18,8 @@ type Linter struct {
 	checkers map[string]RuleChecker
 	astRules map[string]ast.ASTRule // AST-based rules for improved analysis
 	useAST   bool                   // Whether to prefer AST-based rules
+	target   *Target                // Hardware profile passed to rules, nil when none
+	cache    *Cache                 // On-disk result cache used by LintFile and LintFiles, nil when disabled
 }
 
 // NewLinter creates a new linter instance
@@ -63,6 +66,16 @@ func (l *Linter) LoadRules() error {
 	return nil
 }
 
+// SetTarget sets the hardware target profile that programs are checked against
+func (l *Linter) SetTarget(target *Target) {
+	l.target = target
+}
+
+// SetCache sets the on-disk cache LintFile and LintFiles reuse results from; nil disables it
+func (l *Linter) SetCache(cache *Cache) {
+	l.cache = cache
+}
+
 // GetRules returns the loaded rules
 func (l *Linter) GetRules() []*Rule {
 	return l.rules
@@ -89,6 +102,7 @@ func (l *Linter) LintContent(content string, filename string) ([]*Violation, err
 		Content:  content,
 		Program:  result.Program,
 		UsageMap: usageMap,
+		Target:   l.target,
 	}
 
 	var allViolations []*Violation
@@ -104,9 +118,7 @@ func (l *Linter) LintContent(content string, filename string) ([]*Violation, err
 		// Try AST-based rule first if available and enabled
 		if l.useAST {
 			if astRule, exists := l.astRules[rule.ID]; exists && astRule != nil {
-				astCtx := l.convertToASTContext(context)
-				astViolations := astRule.CheckAST(result.Program, astCtx)
-				violations = l.convertASTViolations(astViolations)
+				violations = astRule.CheckAST(result.Program, l.ruleContext(context, rule))
 			} else {
 				// Fall back to text-based checker
 				checker := l.checkers[rule.ID]
@@ -125,7 +137,7 @@ func (l *Linter) LintContent(content string, filename string) ([]*Violation, err
 		// Set rule reference for each violation
 		for _, violation := range violations {
 			violation.Rule = rule
-			violation.Severity = rule.Level
+			violation.Severity = violation.Severity.AtMost(rule.Level)
 		}
 
 		allViolations = append(allViolations, violations...)
@@ -134,21 +146,63 @@ func (l *Linter) LintContent(content string, filename string) ([]*Violation, err
 	return allViolations, nil
 }
 
+// parseSource parses QASM source for linting files, collecting syntax errors;
+// tests replace it to count parses
+var parseSource = func(content string) *parser.ParseResult {
+	options := parser.DefaultParseOptions()
+	options.ErrorRecovery = false
+	return parser.NewParserWithOptions(options).ParseWithErrors(content)
+}
+
+// syntaxError summarizes the syntax errors of a parse result, nil when there are none
+func syntaxError(result *parser.ParseResult) error {
+	if !result.HasErrors() {
+		return nil
+	}
+	err := &result.Errors[0]
+	if len(result.Errors) == 1 {
+		return err
+	}
+	return fmt.Errorf("%w (and %d more)", err, len(result.Errors)-1)
+}
+
+// CheckSyntax returns the syntax errors of QASM source. LintContent recovers
+// from syntax errors, so that editors keep their diagnostics while typing, and
+// callers that must fail on them check the source first.
+func CheckSyntax(content string) error {
+	return syntaxError(parseSource(content))
+}
+
 // LintFile lints a single QASM file
 func (l *Linter) LintFile(filename string) ([]*Violation, error) {
-	// Parse the file
-	p := parser.NewParser()
+	violations, _, err := l.lintFile(filename, l.cacheFingerprint())
+	return violations, err
+}
+
+// lintFile lints a single QASM file and returns its parsed program, which is
+// nil when the result comes from the cache. fingerprint is the linter
+// fingerprint of the run, computed once for all of its files.
+func (l *Linter) lintFile(filename, fingerprint string) ([]*Violation, *parser.Program, error) {
 	content, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	result := p.ParseWithErrors(string(content))
-	if result.HasErrors() {
-		return nil, fmt.Errorf("failed to parse file: %v", result.Errors)
+	// Reuse the result of an earlier run when nothing has changed
+	var cacheKey string
+	if l.cache != nil {
+		cacheKey = l.cache.key(filename, string(content), fingerprint)
+		if violations, ok := l.cache.lookup(cacheKey, l.rules); ok {
+			return violations, nil, nil
+		}
+	}
+
+	result := parseSource(string(content))
+	if err := syntaxError(result); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse file: %w", err)
 	}
 	if result.Program == nil {
-		return nil, fmt.Errorf("failed to parse file: program is nil")
+		return nil, nil, fmt.Errorf("failed to parse file: program is nil")
 	}
 
 	// Build usage map for symbol tracking
@@ -160,6 +214,7 @@ func (l *Linter) LintFile(filename string) ([]*Violation, error) {
 		Content:  string(content),
 		Program:  result.Program,
 		UsageMap: usageMap,
+		Target:   l.target,
 	}
 
 	var allViolations []*Violation
@@ -175,9 +230,7 @@ func (l *Linter) LintFile(filename string) ([]*Violation, error) {
 		// Try AST-based rule first if available and enabled
 		if l.useAST {
 			if astRule, exists := l.astRules[rule.ID]; exists && astRule != nil {
-				astCtx := l.convertToASTContext(context)
-				astViolations := astRule.CheckAST(result.Program, astCtx)
-				violations = l.convertASTViolations(astViolations)
+				violations = astRule.CheckAST(result.Program, l.ruleContext(context, rule))
 			} else {
 				// Fall back to text-based checker
 				checker := l.checkers[rule.ID]
@@ -196,13 +249,17 @@ func (l *Linter) LintFile(filename string) ([]*Violation, error) {
 		// Set rule reference for each violation
 		for _, violation := range violations {
 			violation.Rule = rule
-			violation.Severity = rule.Level
+			violation.Severity = violation.Severity.AtMost(rule.Level)
 		}
 
 		allViolations = append(allViolations, violations...)
 	}
 
-	return allViolations, nil
+	if l.cache != nil {
+		l.cache.store(cacheKey, filename, result.Program, allViolations)
+	}
+
+	return allViolations, result.Program, nil
 }
 
 // runRuleOnProgram runs a single rule against the entire program
@@ -241,7 +298,7 @@ func (l *Linter) matchesRule(rule *Rule, node parser.Node) bool {
 		switch rule.Match.Kind {
 		case "qubit":
 			if decl, ok := node.(*parser.QuantumDeclaration); ok {
-				return decl.Type == "qubit"
+				return decl.Type == "qubit" || decl.Type == "qreg"
 			}
 			return false
 		case "gate":
@@ -308,25 +365,35 @@ func (l *Linter) extractBaseName(name string) string {
 	return name
 }
 
-// LintFiles lints multiple files
+// LintFiles lints multiple files, followed by the project rules checking them together
 func (l *Linter) LintFiles(filenames []string) ([]*Violation, error) {
 	var allViolations []*Violation
+	programs := make(map[string]*parser.Program)
+	fingerprint := l.cacheFingerprint()
 
 	for _, filename := range filenames {
-		violations, err := l.LintFile(filename)
+		violations, program, err := l.lintFile(filename, fingerprint)
 		if err != nil {
 			return nil, fmt.Errorf("failed to lint %s: %w", filename, err)
 		}
+		if program != nil {
+			programs[projectKey(filename)] = program
+		}
 		allViolations = append(allViolations, violations...)
 	}
 
-	return allViolations, nil
+	projectViolations, err := l.lintProject(filenames, programs)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(allViolations, projectViolations...), nil
 }
 
-// LintDirectory lints all QASM files in a directory
+// LintDirectory lints the QASM files in a directory and its subdirectories,
+// skipping files listed in .gitignore and .qasmignore
 func (l *Linter) LintDirectory(dir string) ([]*Violation, error) {
-	pattern := filepath.Join(dir, "*.qasm")
-	files, err := filepath.Glob(pattern)
+	files, err := discover.Files([]string{filepath.Join(dir, "...")}, discover.Options{})
 	if err != nil {
 		return nil, err
 	}
@@ -334,31 +401,9 @@ func (l *Linter) LintDirectory(dir string) ([]*Violation, error) {
 	return l.LintFiles(files)
 }
 
-// convertToASTContext converts from lint.CheckContext to ast.CheckContext
-func (l *Linter) convertToASTContext(ctx *CheckContext) *ast.CheckContext {
-	return &ast.CheckContext{
-		File:     ctx.File,
-		Content:  ctx.Content,
-		Program:  ctx.Program,
-		UsageMap: ctx.UsageMap,
-	}
-}
-
-// convertASTViolations converts from ast.Violation to lint.Violation
-func (l *Linter) convertASTViolations(astViolations []*ast.Violation) []*Violation {
-	violations := make([]*Violation, len(astViolations))
-	for i, astViol := range astViolations {
-		violations[i] = &Violation{
-			Rule: &Rule{
-				ID: astViol.Rule.ID,
-			},
-			Message:  astViol.Message,
-			File:     astViol.File,
-			Line:     astViol.Line,
-			Column:   astViol.Column,
-			Severity: Severity(astViol.Severity), // Convert severity
-			NodeName: astViol.NodeName,
-		}
-	}
-	return violations
+// ruleContext returns a copy of the check context for running a single rule
+func (l *Linter) ruleContext(ctx *CheckContext, rule *Rule) *CheckContext {
+	ruleCtx := *ctx
+	ruleCtx.Rule = rule
+	return &ruleCtx
 }
@@ -0,0 +1,64 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/orangekame3/qasmtools/lint/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Target describes a hardware target profile (native gates, qubit count and
+// coupling map) that programs are checked against
+type Target = core.Target
+
+// LoadTarget reads a target profile from a YAML or JSON file. The profile is
+// named after the file when it does not set a name itself.
+func LoadTarget(path string) (*Target, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target: %w", err)
+	}
+
+	target := &Target{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, target)
+	} else {
+		err = yaml.Unmarshal(data, target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse target %s: %w", path, err)
+	}
+
+	if target.Name == "" {
+		target.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := validateTarget(target); err != nil {
+		return nil, fmt.Errorf("invalid target %s: %w", path, err)
+	}
+
+	return target, nil
+}
+
+// validateTarget checks that the coupling map only refers to existing qubits
+func validateTarget(target *Target) error {
+	if target.Qubits < 0 {
+		return fmt.Errorf("qubits must not be negative, got %d", target.Qubits)
+	}
+
+	for _, edge := range target.CouplingMap {
+		for _, qubit := range edge {
+			if qubit < 0 || (target.Qubits > 0 && qubit >= target.Qubits) {
+				return fmt.Errorf("coupling edge [%d, %d] refers to qubit %d, but the target has %d qubits", edge[0], edge[1], qubit, target.Qubits)
+			}
+		}
+		if edge[0] == edge[1] {
+			return fmt.Errorf("coupling edge [%d, %d] connects a qubit to itself", edge[0], edge[1])
+		}
+	}
+
+	return nil
+}
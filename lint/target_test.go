@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTarget writes a target profile to a temporary file
+func writeTarget(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write target: %v", err)
+	}
+	return path
+}
+
+func TestLoadTarget(t *testing.T) {
+	yamlPath := writeTarget(t, "device.yaml", `qubits: 3
+native_gates: [rz, sx, x, cx]
+coupling_map:
+  - [0, 1]
+  - [1, 2]
+`)
+	jsonPath := writeTarget(t, "device.json", `{"name": "line", "qubits": 3, "native_gates": ["rz", "sx", "x", "cx"], "coupling_map": [[0, 1], [1, 2]], "directed": true}`)
+
+	target, err := LoadTarget(yamlPath)
+	if err != nil {
+		t.Fatalf("Failed to load YAML target: %v", err)
+	}
+	if target.Name != "device" {
+		t.Errorf("Expected target to be named after its file, got %q", target.Name)
+	}
+	if target.Qubits != 3 || len(target.NativeGates) != 4 || len(target.CouplingMap) != 2 {
+		t.Errorf("Unexpected YAML target: %+v", target)
+	}
+	if !target.Coupled(1, 0) || target.Coupled(0, 2) {
+		t.Errorf("Expected undirected coupling 0-1 and no coupling 0-2")
+	}
+
+	target, err = LoadTarget(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to load JSON target: %v", err)
+	}
+	if target.Name != "line" {
+		t.Errorf("Expected target name 'line', got %q", target.Name)
+	}
+	if !target.Coupled(0, 1) || target.Coupled(1, 0) {
+		t.Errorf("Expected directed coupling 0 -> 1 only")
+	}
+}
+
+func TestLoadTargetInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errText string
+	}{
+		{"edge out of range", "qubits: 2\ncoupling_map: [[0, 2]]\n", "refers to qubit 2"},
+		{"self loop", "coupling_map: [[1, 1]]\n", "connects a qubit to itself"},
+		{"negative qubits", "qubits: -1\n", "must not be negative"},
+		{"malformed", "qubits: [\n", "failed to parse target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTarget(writeTarget(t, "device.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.errText) {
+				t.Errorf("Expected error containing %q, got %v", tt.errText, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package features
 
 import (
+	"unicode/utf16"
+
 	"github.com/tliron/commonlog"
 	"github.com/tliron/glsp"
 	protocol "github.com/tliron/glsp/protocol_3_16"
@@ -14,6 +16,18 @@ type DiagnosticsProvider struct {
 	log    commonlog.Logger
 }
 
+// diagnosticData is published as the data of a diagnostic, so that clients can
+// apply the fix of a violation without asking for code actions
+type diagnosticData struct {
+	Fix *diagnosticFix `json:"fix,omitempty"`
+}
+
+// diagnosticFix is the fix of a violation with its edits in LSP positions
+type diagnosticFix struct {
+	Description string              `json:"description"`
+	Edits       []protocol.TextEdit `json:"edits"`
+}
+
 // NewDiagnosticsProvider creates a new diagnostics provider
 func NewDiagnosticsProvider(linter *lint.Linter, log commonlog.Logger) *DiagnosticsProvider {
 	return &DiagnosticsProvider{
@@ -28,7 +42,7 @@ func (d *DiagnosticsProvider) PublishDiagnostics(context *glsp.Context, uri prot
 	violations := d.runLinting(content, string(uri))
 	
 	// Convert violations to diagnostics
-	diagnostics := d.convertViolationsToDiagnostics(violations)
+	diagnostics := d.convertViolationsToDiagnostics(violations, content)
 	
 	// Publish diagnostics
 	d.log.Info("Publishing diagnostics", "uri", uri, "count", len(diagnostics))
@@ -55,42 +69,163 @@ func (d *DiagnosticsProvider) runLinting(content, filename string) []*lint.Viola
 }
 
 // convertViolationsToDiagnostics converts lint violations to LSP diagnostics
-func (d *DiagnosticsProvider) convertViolationsToDiagnostics(violations []*lint.Violation) []protocol.Diagnostic {
+func (d *DiagnosticsProvider) convertViolationsToDiagnostics(violations []*lint.Violation, content string) []protocol.Diagnostic {
 	var diagnostics []protocol.Diagnostic
 	
 	for _, violation := range violations {
-		severity := d.convertSeverity(string(violation.Severity))
-		code := protocol.IntegerOrString{Value: violation.Rule.ID}
-		source := "qasm-lint"
-		
-		diagnostic := protocol.Diagnostic{
-			Range: protocol.Range{
-				Start: protocol.Position{
-					Line:      uint32(violation.Line - 1), // LSP uses 0-based line numbers
-					Character: uint32(violation.Column - 1), // LSP uses 0-based column numbers
-				},
-				End: protocol.Position{
-					Line:      uint32(violation.Line - 1),
-					Character: uint32(violation.Column), // End at next character for highlighting
+		diagnostics = append(diagnostics, d.convertViolation(violation, content))
+	}
+	
+	return diagnostics
+}
+
+// CodeActions returns quick fixes for the violations in a document whose
+// diagnostics overlap the given range
+func (d *DiagnosticsProvider) CodeActions(uri protocol.DocumentUri, content string, rng protocol.Range) []protocol.CodeAction {
+	actions := []protocol.CodeAction{}
+	kind := protocol.CodeActionKindQuickFix
+
+	for _, violation := range d.runLinting(content, string(uri)) {
+		if violation.Fix == nil || len(violation.Fix.Edits) == 0 {
+			continue
+		}
+		diagnostic := d.convertViolation(violation, content)
+		if !rangesOverlap(diagnostic.Range, rng) {
+			continue
+		}
+		actions = append(actions, protocol.CodeAction{
+			Title:       violation.Fix.Description,
+			Kind:        &kind,
+			Diagnostics: []protocol.Diagnostic{diagnostic},
+			Edit: &protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentUri][]protocol.TextEdit{
+					uri: d.convertFix(violation.Fix, content).Edits,
 				},
 			},
-			Severity: &severity,
-			Code:     &code,
-			Source:   &source,
-			Message:  violation.Message,
+		})
+	}
+
+	return actions
+}
+
+// convertViolation converts a lint violation to an LSP diagnostic
+func (d *DiagnosticsProvider) convertViolation(violation *lint.Violation, content string) protocol.Diagnostic {
+	severity := d.convertSeverity(string(violation.Severity))
+	code := protocol.IntegerOrString{Value: violation.Rule.ID}
+	source := "qasm-lint"
+	
+	diagnostic := protocol.Diagnostic{
+		Range:    d.convertRange(violation),
+		Severity: &severity,
+		Code:     &code,
+		Source:   &source,
+		Message:  violation.Message,
+	}
+
+	// Let editors fade unused code and strike through deprecated constructs
+	for _, tag := range violation.Tags {
+		switch tag {
+		case lint.TagUnnecessary:
+			diagnostic.Tags = append(diagnostic.Tags, protocol.DiagnosticTagUnnecessary)
+		case lint.TagDeprecated:
+			diagnostic.Tags = append(diagnostic.Tags, protocol.DiagnosticTagDeprecated)
 		}
-		
-		// Add documentation URL if available
-		if violation.Rule.DocumentationURL != "" {
-			diagnostic.CodeDescription = &protocol.CodeDescription{
-				HRef: violation.Rule.DocumentationURL,
-			}
+	}
+
+	for _, related := range violation.Related {
+		position := protocol.Position{
+			Line:      uint32(related.Line - 1),
+			Character: uint32(related.Column - 1),
 		}
-		
-		diagnostics = append(diagnostics, diagnostic)
+		diagnostic.RelatedInformation = append(diagnostic.RelatedInformation, protocol.DiagnosticRelatedInformation{
+			Location: protocol.Location{
+				URI:   related.File,
+				Range: protocol.Range{Start: position, End: position},
+			},
+			Message: related.Message,
+		})
 	}
 	
-	return diagnostics
+	if violation.Fix != nil {
+		diagnostic.Data = diagnosticData{Fix: d.convertFix(violation.Fix, content)}
+	}
+
+	// Add documentation URL if available
+	if violation.Rule.DocumentationURL != "" {
+		diagnostic.CodeDescription = &protocol.CodeDescription{
+			HRef: violation.Rule.DocumentationURL,
+		}
+	}
+	
+	return diagnostic
+}
+
+// convertFix converts the edits of a fix to LSP text edits. Edits locate their
+// text by rune offset, which is converted to the UTF-16 positions LSP expects.
+func (d *DiagnosticsProvider) convertFix(fix *lint.Fix, content string) *diagnosticFix {
+	runes := []rune(content)
+	converted := &diagnosticFix{
+		Description: fix.Description,
+		Edits:       make([]protocol.TextEdit, 0, len(fix.Edits)),
+	}
+	for _, edit := range fix.Edits {
+		converted.Edits = append(converted.Edits, protocol.TextEdit{
+			Range: protocol.Range{
+				Start: offsetPosition(runes, edit.Start.Offset),
+				End:   offsetPosition(runes, edit.End.Offset),
+			},
+			NewText: edit.NewText,
+		})
+	}
+	return converted
+}
+
+// offsetPosition converts a rune offset into content to an LSP position
+func offsetPosition(runes []rune, offset int) protocol.Position {
+	offset = max(0, min(offset, len(runes)))
+	var position protocol.Position
+	for _, r := range runes[:offset] {
+		if r == '\n' {
+			position.Line++
+			position.Character = 0
+		} else {
+			position.Character += uint32(utf16.RuneLen(r))
+		}
+	}
+	return position
+}
+
+// rangesOverlap reports whether two ranges share a position; an empty range
+// such as a cursor overlaps the ranges it touches
+func rangesOverlap(a, b protocol.Range) bool {
+	return !positionBefore(a.End, b.Start) && !positionBefore(b.End, a.Start)
+}
+
+// positionBefore reports whether position a comes before position b
+func positionBefore(a, b protocol.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
+
+// convertRange converts the extent of a violation to an LSP range. Violations
+// without an end position highlight the single character at their start.
+func (d *DiagnosticsProvider) convertRange(violation *lint.Violation) protocol.Range {
+	start := protocol.Position{
+		Line:      uint32(violation.Line - 1),   // LSP uses 0-based line numbers
+		Character: uint32(violation.Column - 1), // LSP uses 0-based column numbers
+	}
+	end := protocol.Position{
+		Line:      start.Line,
+		Character: start.Character + 1,
+	}
+	if violation.EndLine > 0 {
+		// EndColumn is the 1-based column of the last character, which is the
+		// 0-based exclusive end LSP expects
+		end = protocol.Position{
+			Line:      uint32(violation.EndLine - 1),
+			Character: uint32(violation.EndColumn),
+		}
+	}
+	return protocol.Range{Start: start, End: end}
 }
 
 // convertSeverity converts lint severity to LSP diagnostic severity
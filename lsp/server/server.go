@@ -80,6 +80,7 @@ func (s *Server) setupHandlers(version string) {
 		TextDocumentDidChange:          s.textDocumentDidChange,
 		TextDocumentSemanticTokensFull: s.textDocumentSemanticTokensFull,
 		TextDocumentFormatting:         s.textDocumentFormatting,
+		TextDocumentCodeAction:         s.textDocumentCodeAction,
 	}
 }
 
@@ -190,4 +191,15 @@ func (s *Server) textDocumentFormatting(context *glsp.Context, params *protocol.
 	s.docManager.UpdateContent(uri, formatted)
 	
 	return s.formatting.CreateTextEdit(content, formatted), nil
-}
\ No newline at end of file
+}
+
+func (s *Server) textDocumentCodeAction(context *glsp.Context, params *protocol.CodeActionParams) (any, error) {
+	uri := params.TextDocument.URI
+	content, exists := s.docManager.GetContent(uri)
+	if !exists {
+		s.log.Error("Document not found for code actions", "uri", uri)
+		return []protocol.CodeAction{}, nil
+	}
+
+	return s.diagnostics.CodeActions(uri, content, params.Range), nil
+}
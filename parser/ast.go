@@ -100,6 +100,7 @@ type ClassicalDeclaration struct {
 	Size        Expression `json:"size,omitempty"` // for bit[n], int[32], etc.
 	Identifier  string     `json:"identifier"`
 	Initializer Expression `json:"initializer,omitempty"`
+	Const       bool       `json:"const,omitempty"`     // for const declarations
 	TypeInfo    *TypeInfo  `json:"type_info,omitempty"` // Type information
 }
 
@@ -108,6 +109,18 @@ func (c *ClassicalDeclaration) String() string {
 	return "ClassicalDeclaration: " + c.Identifier
 }
 
+// AliasDeclaration represents let statements
+type AliasDeclaration struct {
+	BaseNode
+	Identifier string     `json:"identifier"`
+	Value      Expression `json:"value"` // concatenations use the "++" operator
+}
+
+func (a *AliasDeclaration) StatementNode() {}
+func (a *AliasDeclaration) String() string {
+	return "AliasDeclaration: " + a.Identifier
+}
+
 // GateCall represents gate applications
 type GateCall struct {
 	BaseNode
@@ -145,6 +158,41 @@ func (m *Measurement) String() string {
 	return "Measurement"
 }
 
+// Reset represents reset statements
+type Reset struct {
+	BaseNode
+	Qubit Expression `json:"qubit"`
+}
+
+func (r *Reset) StatementNode() {}
+func (r *Reset) String() string {
+	return "Reset"
+}
+
+// Assignment represents classical assignments like x = 1 or x += 1
+type Assignment struct {
+	BaseNode
+	Target   Expression `json:"target"`
+	Operator string     `json:"operator"`
+	Value    Expression `json:"value"`
+}
+
+func (a *Assignment) StatementNode() {}
+func (a *Assignment) String() string {
+	return "Assignment"
+}
+
+// ExpressionStatement represents an expression evaluated for its effect, such as a subroutine call
+type ExpressionStatement struct {
+	BaseNode
+	Expression Expression `json:"expression"`
+}
+
+func (e *ExpressionStatement) StatementNode() {}
+func (e *ExpressionStatement) String() string {
+	return "ExpressionStatement"
+}
+
 // Include represents include statements
 type Include struct {
 	BaseNode
@@ -170,6 +218,20 @@ func (g *GateDefinition) String() string {
 	return "GateDefinition: " + g.Name
 }
 
+// SubroutineDefinition represents subroutine definitions (def)
+type SubroutineDefinition struct {
+	BaseNode
+	Name       string      `json:"name"`
+	Parameters []Parameter `json:"parameters,omitempty"`
+	ReturnType string      `json:"return_type,omitempty"`
+	Body       []Statement `json:"body"`
+}
+
+func (s *SubroutineDefinition) StatementNode() {}
+func (s *SubroutineDefinition) String() string {
+	return "SubroutineDefinition: " + s.Name
+}
+
 // Parameter represents function/gate parameters
 type Parameter struct {
 	BaseNode
@@ -219,6 +281,72 @@ func (w *WhileStatement) String() string {
 	return "WhileStatement"
 }
 
+// DelayStatement represents delay instructions (delay[100ns] q;). An empty
+// qubit list delays all qubits.
+type DelayStatement struct {
+	BaseNode
+	Duration Expression   `json:"duration"`
+	Qubits   []Expression `json:"qubits,omitempty"`
+}
+
+func (d *DelayStatement) StatementNode() {}
+func (d *DelayStatement) String() string {
+	return "DelayStatement"
+}
+
+// BoxStatement represents box blocks with an optional declared duration
+type BoxStatement struct {
+	BaseNode
+	Duration Expression  `json:"duration,omitempty"`
+	Body     []Statement `json:"body"`
+}
+
+func (b *BoxStatement) StatementNode() {}
+func (b *BoxStatement) String() string {
+	return "BoxStatement"
+}
+
+// BreakStatement represents break statements
+type BreakStatement struct {
+	BaseNode
+}
+
+func (b *BreakStatement) StatementNode() {}
+func (b *BreakStatement) String() string {
+	return "BreakStatement"
+}
+
+// ContinueStatement represents continue statements
+type ContinueStatement struct {
+	BaseNode
+}
+
+func (c *ContinueStatement) StatementNode() {}
+func (c *ContinueStatement) String() string {
+	return "ContinueStatement"
+}
+
+// ReturnStatement represents return statements with an optional value
+type ReturnStatement struct {
+	BaseNode
+	Value Expression `json:"value,omitempty"`
+}
+
+func (r *ReturnStatement) StatementNode() {}
+func (r *ReturnStatement) String() string {
+	return "ReturnStatement"
+}
+
+// EndStatement represents end statements that terminate the program
+type EndStatement struct {
+	BaseNode
+}
+
+func (e *EndStatement) StatementNode() {}
+func (e *EndStatement) String() string {
+	return "EndStatement"
+}
+
 // Expression implementations
 
 // Identifier represents variable references
@@ -249,6 +377,7 @@ type RangedIdentifier struct {
 	BaseNode
 	Name     string     `json:"name"`
 	Start    Expression `json:"start"`
+	Step     Expression `json:"step,omitempty"`
 	EndIndex Expression `json:"end"`
 }
 
@@ -257,6 +386,30 @@ func (r *RangedIdentifier) String() string {
 	return "RangedIdentifier: " + r.Name
 }
 
+// RangeExpression represents a standalone range like [0:n] or [0:2:n] used as a loop iterable
+type RangeExpression struct {
+	BaseNode
+	Start    Expression `json:"start,omitempty"`
+	Step     Expression `json:"step,omitempty"`
+	EndIndex Expression `json:"end,omitempty"`
+}
+
+func (r *RangeExpression) ExpressionNode() {}
+func (r *RangeExpression) String() string {
+	return "RangeExpression"
+}
+
+// SetExpression represents a discrete set like {0, 2, 5} used as a loop iterable
+type SetExpression struct {
+	BaseNode
+	Elements []Expression `json:"elements"`
+}
+
+func (s *SetExpression) ExpressionNode() {}
+func (s *SetExpression) String() string {
+	return "SetExpression"
+}
+
 // IntegerLiteral represents integer constants
 type IntegerLiteral struct {
 	BaseNode
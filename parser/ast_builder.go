@@ -73,6 +73,19 @@ func (v *ASTBuilderVisitor) getEndPosition(ctx antlr.ParserRuleContext) Position
 	}
 }
 
+// getTokenPosition extracts position information from a single token
+func (v *ASTBuilderVisitor) getTokenPosition(token antlr.Token) Position {
+	if token == nil {
+		return Position{Line: 1, Column: 1}
+	}
+
+	return Position{
+		Line:   token.GetLine(),
+		Column: token.GetColumn() + 1, // ANTLR uses 0-based columns
+		Offset: token.GetStart(),
+	}
+}
+
 // createBaseNode creates a BaseNode with position information
 func (v *ASTBuilderVisitor) createBaseNode(ctx antlr.ParserRuleContext) BaseNode {
 	return BaseNode{
@@ -91,10 +104,14 @@ func (v *ASTBuilderVisitor) VisitProgram(ctx *qasm_gen.ProgramContext) interface
 	}
 
 	// Parse version if present
-	if ctx.Version() != nil {
+	if versionCtx := ctx.Version(); versionCtx != nil {
+		number := "3.0"
+		if specifier := versionCtx.VersionSpecifier(); specifier != nil {
+			number = specifier.GetText()
+		}
 		program.Version = &Version{
-			BaseNode: v.createBaseNode(ctx),
-			Number:   "3.0",
+			BaseNode: v.createBaseNode(versionCtx),
+			Number:   number,
 		}
 	}
 
@@ -150,11 +167,26 @@ func (v *ASTBuilderVisitor) visitStatement(ctx qasm_gen.IStatementContext) State
 		return v.visitQuantumDeclarationStatement(quantumDeclCtx)
 	}
 
+	// Check for OpenQASM 2 register declarations (qreg/creg)
+	if oldStyleCtx := ctx.OldStyleDeclarationStatement(); oldStyleCtx != nil {
+		return v.visitOldStyleDeclarationStatement(oldStyleCtx)
+	}
+
 	// Check for classical declaration statement
 	if classicalDeclCtx := ctx.ClassicalDeclarationStatement(); classicalDeclCtx != nil {
 		return v.visitClassicalDeclarationStatement(classicalDeclCtx)
 	}
 
+	// Check for const declaration statement
+	if constDeclCtx := ctx.ConstDeclarationStatement(); constDeclCtx != nil {
+		return v.visitConstDeclarationStatement(constDeclCtx)
+	}
+
+	// Check for alias declaration statement (let)
+	if aliasCtx := ctx.AliasDeclarationStatement(); aliasCtx != nil {
+		return v.visitAliasDeclarationStatement(aliasCtx)
+	}
+
 	// Check for include statement
 	if includeCtx := ctx.IncludeStatement(); includeCtx != nil {
 		return v.visitIncludeStatement(includeCtx)
@@ -170,6 +202,58 @@ func (v *ASTBuilderVisitor) visitStatement(ctx qasm_gen.IStatementContext) State
 		return v.visitMeasureArrowAssignmentStatement(measureCtx)
 	}
 
+	// Check for reset statement
+	if resetCtx := ctx.ResetStatement(); resetCtx != nil {
+		return v.visitResetStatement(resetCtx)
+	}
+
+	// Check for gate definition
+	if gateCtx := ctx.GateStatement(); gateCtx != nil {
+		return v.visitGateStatement(gateCtx)
+	}
+
+	// Check for subroutine definition
+	if defCtx := ctx.DefStatement(); defCtx != nil {
+		return v.visitDefStatement(defCtx)
+	}
+
+	// Check for if statement
+	if ifCtx := ctx.IfStatement(); ifCtx != nil {
+		return v.visitIfStatement(ifCtx)
+	}
+
+	// Check for for loop
+	if forCtx := ctx.ForStatement(); forCtx != nil {
+		return v.visitForStatement(forCtx)
+	}
+
+	// Check for while loop
+	if whileCtx := ctx.WhileStatement(); whileCtx != nil {
+		return v.visitWhileStatement(whileCtx)
+	}
+
+	// Check for timing instructions
+	if delayCtx := ctx.DelayStatement(); delayCtx != nil {
+		return v.visitDelayStatement(delayCtx)
+	}
+	if boxCtx := ctx.BoxStatement(); boxCtx != nil {
+		return v.visitBoxStatement(boxCtx)
+	}
+
+	// Check for control flow terminators
+	if breakCtx := ctx.BreakStatement(); breakCtx != nil {
+		return &BreakStatement{BaseNode: v.createBaseNode(breakCtx)}
+	}
+	if continueCtx := ctx.ContinueStatement(); continueCtx != nil {
+		return &ContinueStatement{BaseNode: v.createBaseNode(continueCtx)}
+	}
+	if returnCtx := ctx.ReturnStatement(); returnCtx != nil {
+		return v.visitReturnStatement(returnCtx)
+	}
+	if endCtx := ctx.EndStatement(); endCtx != nil {
+		return &EndStatement{BaseNode: v.createBaseNode(endCtx)}
+	}
+
 	// Check for expression statement (other expressions)
 	if exprCtx := ctx.ExpressionStatement(); exprCtx != nil {
 		return v.visitExpressionStatement(exprCtx)
@@ -219,24 +303,47 @@ func (v *ASTBuilderVisitor) visitQuantumDeclarationStatement(ctx qasm_gen.IQuant
 	}
 }
 
-// visitDesignator handles array designators like [2] in qubit[2]
-func (v *ASTBuilderVisitor) visitDesignator(ctx qasm_gen.IDesignatorContext) Expression {
+// visitOldStyleDeclarationStatement handles OpenQASM 2 register declarations
+// (qreg q[2]; creg c[2];), keeping the keyword as the declaration type
+func (v *ASTBuilderVisitor) visitOldStyleDeclarationStatement(ctx qasm_gen.IOldStyleDeclarationStatementContext) Statement {
 	if ctx == nil {
 		return nil
 	}
 
-	// For now, handle simple integer designators
-	// This is a simplified implementation - full implementation would handle expressions
-	text := ctx.GetText()
-	// Remove brackets and parse as integer
-	if len(text) >= 3 && text[0] == '[' && text[len(text)-1] == ']' {
-		sizeStr := text[1 : len(text)-1]
-		if val := parseInt(sizeStr); val > 0 {
-			return &IntegerLiteral{Value: val}
+	var identifier string
+	if idNode := ctx.Identifier(); idNode != nil {
+		identifier = idNode.GetText()
+	}
+
+	var size Expression
+	if designator := ctx.Designator(); designator != nil {
+		size = v.visitDesignator(designator)
+	}
+
+	if ctx.CREG() != nil {
+		return &ClassicalDeclaration{
+			BaseNode:   v.createBaseNode(ctx),
+			Type:       "creg",
+			Size:       size,
+			Identifier: identifier,
 		}
 	}
 
-	return nil
+	return &QuantumDeclaration{
+		BaseNode:   v.createBaseNode(ctx),
+		Type:       "qreg",
+		Size:       size,
+		Identifier: identifier,
+	}
+}
+
+// visitDesignator handles array designators like [2] in qubit[2]
+func (v *ASTBuilderVisitor) visitDesignator(ctx qasm_gen.IDesignatorContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	return v.visitExpression(ctx.Expression())
 }
 
 // visitClassicalDeclarationStatement handles classical declarations using ANTLR context
@@ -280,7 +387,50 @@ func (v *ASTBuilderVisitor) visitClassicalDeclarationStatement(ctx qasm_gen.ICla
 		Type:        declType,
 		Size:        size,
 		Identifier:  identifier,
-		Initializer: nil, // TODO: Handle initializers if needed
+		Initializer: v.visitDeclarationExpression(ctx.DeclarationExpression()),
+	}
+}
+
+// visitConstDeclarationStatement handles const declarations (const int n = 4;)
+func (v *ASTBuilderVisitor) visitConstDeclarationStatement(ctx qasm_gen.IConstDeclarationStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	var identifier string
+	if idNode := ctx.Identifier(); idNode != nil {
+		identifier = idNode.GetText()
+	}
+
+	var declType string
+	if scalarType := ctx.ScalarType(); scalarType != nil {
+		declType = scalarType.GetText()
+	}
+
+	return &ClassicalDeclaration{
+		BaseNode:    v.createBaseNode(ctx),
+		Type:        declType,
+		Identifier:  identifier,
+		Initializer: v.visitDeclarationExpression(ctx.DeclarationExpression()),
+		Const:       true,
+	}
+}
+
+// visitAliasDeclarationStatement handles alias declarations (let a = q[0:1];)
+func (v *ASTBuilderVisitor) visitAliasDeclarationStatement(ctx qasm_gen.IAliasDeclarationStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	var identifier string
+	if idNode := ctx.Identifier(); idNode != nil {
+		identifier = idNode.GetText()
+	}
+
+	return &AliasDeclaration{
+		BaseNode:   v.createBaseNode(ctx),
+		Identifier: identifier,
+		Value:      v.visitAliasExpression(ctx.AliasExpression()),
 	}
 }
 
@@ -374,16 +524,237 @@ func (v *ASTBuilderVisitor) visitMeasureArrowAssignmentStatement(ctx qasm_gen.IM
 
 // visitExpressionStatement handles expression statements (other expressions)
 func (v *ASTBuilderVisitor) visitExpressionStatement(ctx qasm_gen.IExpressionStatementContext) Statement {
-	// For now, we don't handle general expressions as statements
-	// Most relevant expressions (gate calls, measurements) have their own statement types
-	return nil
+	if ctx == nil {
+		return nil
+	}
+
+	// Most relevant expressions (gate calls, measurements) have their own statement types;
+	// the remaining ones are mainly subroutine calls
+	return &ExpressionStatement{
+		BaseNode:   v.createBaseNode(ctx),
+		Expression: v.visitExpression(ctx.Expression()),
+	}
 }
 
 // visitAssignmentStatement handles assignment statements (other assignments)
 func (v *ASTBuilderVisitor) visitAssignmentStatement(ctx qasm_gen.IAssignmentStatementContext) Statement {
-	// For now, we handle measurements separately
-	// Other assignments might be classical variable assignments
-	return nil
+	if ctx == nil {
+		return nil
+	}
+
+	// Measurement assignments (c = measure q) are represented as measurements
+	if measureExpr := ctx.MeasureExpression(); measureExpr != nil {
+		var target Expression
+		if targetCtx := ctx.IndexedIdentifier(); targetCtx != nil {
+			target = v.visitIndexedIdentifier(targetCtx)
+		}
+
+		return &Measurement{
+			BaseNode: v.createBaseNode(ctx),
+			Qubit:    v.visitMeasureExpression(measureExpr),
+			Target:   target,
+		}
+	}
+
+	var target Expression
+	if targetCtx := ctx.IndexedIdentifier(); targetCtx != nil {
+		target = v.visitIndexedIdentifier(targetCtx)
+	}
+
+	operator := "="
+	if op := ctx.GetOp(); op != nil {
+		operator = op.GetText()
+	}
+
+	return &Assignment{
+		BaseNode: v.createBaseNode(ctx),
+		Target:   target,
+		Operator: operator,
+		Value:    v.visitExpression(ctx.Expression()),
+	}
+}
+
+// visitResetStatement handles reset statements
+func (v *ASTBuilderVisitor) visitResetStatement(ctx qasm_gen.IResetStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	return &Reset{
+		BaseNode: v.createBaseNode(ctx),
+		Qubit:    v.visitGateOperand(ctx.GateOperand()),
+	}
+}
+
+// visitIfStatement handles if/else statements
+func (v *ASTBuilderVisitor) visitIfStatement(ctx qasm_gen.IIfStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	return &IfStatement{
+		BaseNode:  v.createBaseNode(ctx),
+		Condition: v.visitExpression(ctx.Expression()),
+		ThenBody:  v.visitBody(ctx.GetIf_body()),
+		ElseBody:  v.visitBody(ctx.GetElse_body()),
+	}
+}
+
+// visitForStatement handles for loops over ranges, sets and expressions
+func (v *ASTBuilderVisitor) visitForStatement(ctx qasm_gen.IForStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	forStmt := &ForStatement{
+		BaseNode: v.createBaseNode(ctx),
+		Body:     v.visitBody(ctx.GetBody()),
+	}
+	if idNode := ctx.Identifier(); idNode != nil {
+		forStmt.Variable = idNode.GetText()
+	}
+
+	switch {
+	case ctx.RangeExpression() != nil:
+		forStmt.Iterable = v.visitRangeExpression(ctx.RangeExpression())
+	case ctx.SetExpression() != nil:
+		forStmt.Iterable = v.visitSetExpression(ctx.SetExpression())
+	default:
+		forStmt.Iterable = v.visitExpression(ctx.Expression())
+	}
+
+	return forStmt
+}
+
+// visitWhileStatement handles while loops
+func (v *ASTBuilderVisitor) visitWhileStatement(ctx qasm_gen.IWhileStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	return &WhileStatement{
+		BaseNode:  v.createBaseNode(ctx),
+		Condition: v.visitExpression(ctx.Expression()),
+		Body:      v.visitBody(ctx.GetBody()),
+	}
+}
+
+// visitDefStatement handles subroutine definitions
+func (v *ASTBuilderVisitor) visitDefStatement(ctx qasm_gen.IDefStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	subroutine := &SubroutineDefinition{
+		BaseNode: v.createBaseNode(ctx),
+	}
+	if idNode := ctx.Identifier(); idNode != nil {
+		subroutine.Name = idNode.GetText()
+	}
+	if argsCtx := ctx.ArgumentDefinitionList(); argsCtx != nil {
+		for _, argCtx := range argsCtx.AllArgumentDefinition() {
+			param := Parameter{BaseNode: v.createBaseNode(argCtx)}
+			if idNode := argCtx.Identifier(); idNode != nil {
+				param.Name = idNode.GetText()
+				param.BaseNode = BaseNode{Position: v.getTokenPosition(idNode.GetSymbol())}
+			}
+			// The type is the argument text before its name
+			param.Type = strings.TrimSuffix(argCtx.GetText(), param.Name)
+			subroutine.Parameters = append(subroutine.Parameters, param)
+		}
+	}
+	if returnCtx := ctx.ReturnSignature(); returnCtx != nil && returnCtx.ScalarType() != nil {
+		subroutine.ReturnType = returnCtx.ScalarType().GetText()
+	}
+	subroutine.Body = v.visitScope(ctx.Scope())
+
+	return subroutine
+}
+
+// visitDelayStatement handles delay instructions (delay[100ns] q;)
+func (v *ASTBuilderVisitor) visitDelayStatement(ctx qasm_gen.IDelayStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	var duration Expression
+	if designator := ctx.Designator(); designator != nil {
+		duration = v.visitExpression(designator.Expression())
+	}
+
+	return &DelayStatement{
+		BaseNode: v.createBaseNode(ctx),
+		Duration: duration,
+		Qubits:   v.visitGateOperandList(ctx.GateOperandList()),
+	}
+}
+
+// visitBoxStatement handles box blocks (box[200ns] { ... })
+func (v *ASTBuilderVisitor) visitBoxStatement(ctx qasm_gen.IBoxStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	var duration Expression
+	if designator := ctx.Designator(); designator != nil {
+		duration = v.visitExpression(designator.Expression())
+	}
+
+	return &BoxStatement{
+		BaseNode: v.createBaseNode(ctx),
+		Duration: duration,
+		Body:     v.visitScope(ctx.Scope()),
+	}
+}
+
+// visitReturnStatement handles return statements with an optional value or measurement
+func (v *ASTBuilderVisitor) visitReturnStatement(ctx qasm_gen.IReturnStatementContext) Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	returnStmt := &ReturnStatement{BaseNode: v.createBaseNode(ctx)}
+	if exprCtx := ctx.Expression(); exprCtx != nil {
+		returnStmt.Value = v.visitExpression(exprCtx)
+	} else if measureCtx := ctx.MeasureExpression(); measureCtx != nil {
+		returnStmt.Value = v.visitMeasureExpression(measureCtx)
+	}
+	return returnStmt
+}
+
+// visitBody collects the statements of a statement-or-scope body
+func (v *ASTBuilderVisitor) visitBody(ctx qasm_gen.IStatementOrScopeContext) []Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	if scopeCtx := ctx.Scope(); scopeCtx != nil {
+		return v.visitScope(scopeCtx)
+	}
+
+	var body []Statement
+	if stmt := v.visitStatementOrScope(ctx); stmt != nil {
+		body = append(body, stmt)
+	}
+	return body
+}
+
+// visitScope collects the statements of a braced scope
+func (v *ASTBuilderVisitor) visitScope(ctx qasm_gen.IScopeContext) []Statement {
+	if ctx == nil {
+		return nil
+	}
+
+	var body []Statement
+	for _, inner := range ctx.AllStatementOrScope() {
+		if inner.Scope() != nil {
+			// Nested bare scopes are flattened into the enclosing body
+			body = append(body, v.visitScope(inner.Scope())...)
+		} else if stmt := v.visitStatementOrScope(inner); stmt != nil {
+			body = append(body, stmt)
+		}
+	}
+	return body
 }
 
 // visitGateOperandList handles list of gate operands (qubits)
@@ -393,16 +764,9 @@ func (v *ASTBuilderVisitor) visitGateOperandList(ctx qasm_gen.IGateOperandListCo
 	}
 
 	var operands []Expression
-	// For now, use simplified parsing - proper implementation would parse each operand
-	text := ctx.GetText()
-	// Split by commas and create identifiers
-	if text != "" {
-		parts := strings.Split(text, ",")
-		for _, part := range parts {
-			part = strings.TrimSpace(part)
-			if part != "" {
-				operands = append(operands, v.parseQubitExpression(part))
-			}
+	for _, operandCtx := range ctx.AllGateOperand() {
+		if operand := v.visitGateOperand(operandCtx); operand != nil {
+			operands = append(operands, operand)
 		}
 	}
 
@@ -415,48 +779,99 @@ func (v *ASTBuilderVisitor) visitExpressionList(ctx qasm_gen.IExpressionListCont
 		return nil
 	}
 
-	// For now, return empty - parameters are less critical for basic linting
-	return make([]Expression, 0)
+	parameters := make([]Expression, 0)
+	for _, exprCtx := range ctx.AllExpression() {
+		if expr := v.visitExpression(exprCtx); expr != nil {
+			parameters = append(parameters, expr)
+		}
+	}
+
+	return parameters
 }
 
-// visitGateModifier handles gate modifiers
+// visitGateModifier handles gate modifiers (inv @, pow(k) @, ctrl(n) @, negctrl(n) @)
 func (v *ASTBuilderVisitor) visitGateModifier(ctx qasm_gen.IGateModifierContext) *Modifier {
 	if ctx == nil {
 		return nil
 	}
 
-	// For now, return empty modifier - modifiers are less critical for basic linting
-	return &Modifier{
-		Type: "unknown",
+	modifier := &Modifier{
+		BaseNode: v.createBaseNode(ctx),
+		Type:     "unknown",
+	}
+
+	switch {
+	case ctx.INV() != nil:
+		modifier.Type = "inv"
+	case ctx.POW() != nil:
+		modifier.Type = "pow"
+	case ctx.CTRL() != nil:
+		modifier.Type = "ctrl"
+	case ctx.NEGCTRL() != nil:
+		modifier.Type = "negctrl"
+	}
+
+	if exprCtx := ctx.Expression(); exprCtx != nil {
+		modifier.Parameters = []Expression{v.visitExpression(exprCtx)}
 	}
+
+	return modifier
 }
 
-// visitMeasureExpression handles measurement expressions
-func (v *ASTBuilderVisitor) visitMeasureExpression(ctx qasm_gen.IMeasureExpressionContext) Expression {
+// visitGateStatement handles gate definitions
+func (v *ASTBuilderVisitor) visitGateStatement(ctx qasm_gen.IGateStatementContext) Statement {
 	if ctx == nil {
 		return nil
 	}
 
-	// For now, use simplified parsing
-	text := ctx.GetText()
-	// Remove "measure" prefix and parse as qubit expression
-	if strings.HasPrefix(text, "measure") {
-		qubitText := strings.TrimSpace(text[7:]) // Remove "measure"
-		return v.parseQubitExpression(qubitText)
+	gateDef := &GateDefinition{
+		BaseNode:   v.createBaseNode(ctx),
+		Parameters: v.visitIdentifierList(ctx.GetParams()),
+		Qubits:     v.visitIdentifierList(ctx.GetQubits()),
+	}
+	if idNode := ctx.Identifier(); idNode != nil {
+		gateDef.Name = idNode.GetText()
 	}
 
-	return nil
+	if scopeCtx := ctx.Scope(); scopeCtx != nil {
+		for _, inner := range scopeCtx.AllStatementOrScope() {
+			if stmt := v.visitStatementOrScope(inner); stmt != nil {
+				gateDef.Body = append(gateDef.Body, stmt)
+			}
+		}
+	}
+
+	return gateDef
 }
 
-// visitIndexedIdentifier handles indexed identifiers (like c[0])
-func (v *ASTBuilderVisitor) visitIndexedIdentifier(ctx qasm_gen.IIndexedIdentifierContext) Expression {
+// visitIdentifierList converts an identifier list into parameters
+func (v *ASTBuilderVisitor) visitIdentifierList(ctx qasm_gen.IIdentifierListContext) []Parameter {
 	if ctx == nil {
 		return nil
 	}
 
-	// For now, use simplified parsing
-	text := ctx.GetText()
-	return v.parseQubitExpression(text)
+	var params []Parameter
+	for _, idNode := range ctx.AllIdentifier() {
+		params = append(params, Parameter{
+			BaseNode: BaseNode{Position: v.getTokenPosition(idNode.GetSymbol())},
+			Name:     idNode.GetText(),
+		})
+	}
+	return params
+}
+
+// visitMeasureExpression handles measurement expressions
+func (v *ASTBuilderVisitor) visitMeasureExpression(ctx qasm_gen.IMeasureExpressionContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	return v.visitGateOperand(ctx.GateOperand())
+}
+
+// visitIndexedIdentifier handles indexed identifiers (like c[0])
+func (v *ASTBuilderVisitor) visitIndexedIdentifier(ctx qasm_gen.IIndexedIdentifierContext) Expression {
+	return v.visitIndexedIdentifierContext(ctx)
 }
 
 // isGateCall checks if the text represents a gate call
@@ -0,0 +1,315 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/antlr4-go/antlr/v4"
+	qasm_gen "github.com/orangekame3/qasmtools/parser/gen"
+)
+
+// binaryExpressionContext is implemented by all binary operator contexts
+// (additive, multiplicative, comparison, logical, bitwise, power, ...)
+type binaryExpressionContext interface {
+	antlr.ParserRuleContext
+	GetOp() antlr.Token
+	Expression(i int) qasm_gen.IExpressionContext
+}
+
+// visitExpression builds an Expression node from an ANTLR expression context
+func (v *ASTBuilderVisitor) visitExpression(ctx qasm_gen.IExpressionContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	switch c := ctx.(type) {
+	case *qasm_gen.ParenthesisExpressionContext:
+		return &ParenthesizedExpression{
+			BaseNode:   v.createBaseNode(c),
+			Expression: v.visitExpression(c.Expression()),
+		}
+
+	case *qasm_gen.IndexExpressionContext:
+		return v.visitIndexExpression(c)
+
+	case *qasm_gen.UnaryExpressionContext:
+		return &UnaryExpression{
+			BaseNode: v.createBaseNode(c),
+			Operator: c.GetOp().GetText(),
+			Operand:  v.visitExpression(c.Expression()),
+		}
+
+	case *qasm_gen.CastExpressionContext:
+		typeName := ""
+		if c.ScalarType() != nil {
+			typeName = c.ScalarType().GetText()
+		} else if c.ArrayType() != nil {
+			typeName = c.ArrayType().GetText()
+		}
+		return &FunctionCall{
+			BaseNode:  v.createBaseNode(c),
+			Name:      typeName,
+			Arguments: []Expression{v.visitExpression(c.Expression())},
+		}
+
+	case *qasm_gen.CallExpressionContext:
+		call := &FunctionCall{
+			BaseNode:  v.createBaseNode(c),
+			Name:      c.Identifier().GetText(),
+			Arguments: make([]Expression, 0),
+		}
+		if exprList := c.ExpressionList(); exprList != nil {
+			for _, arg := range exprList.AllExpression() {
+				call.Arguments = append(call.Arguments, v.visitExpression(arg))
+			}
+		}
+		return call
+
+	case *qasm_gen.LiteralExpressionContext:
+		return v.visitLiteralExpression(c)
+
+	case binaryExpressionContext:
+		return &BinaryExpression{
+			BaseNode: v.createBaseNode(c),
+			Left:     v.visitExpression(c.Expression(0)),
+			Operator: c.GetOp().GetText(),
+			Right:    v.visitExpression(c.Expression(1)),
+		}
+	}
+
+	// Unsupported expression kinds (e.g. durationof) are kept as opaque identifiers
+	return &Identifier{
+		BaseNode: v.createBaseNode(ctx),
+		Name:     ctx.GetText(),
+	}
+}
+
+// visitIndexExpression handles indexing such as q[0], q[0:2] or a[i + 1]
+func (v *ASTBuilderVisitor) visitIndexExpression(ctx *qasm_gen.IndexExpressionContext) Expression {
+	base := v.visitExpression(ctx.Expression())
+
+	id, ok := base.(*Identifier)
+	if !ok {
+		return base
+	}
+
+	return v.buildIndexedExpression(ctx, id.Name, ctx.IndexOperator())
+}
+
+// visitLiteralExpression handles identifiers and literal values
+func (v *ASTBuilderVisitor) visitLiteralExpression(ctx *qasm_gen.LiteralExpressionContext) Expression {
+	base := v.createBaseNode(ctx)
+	text := ctx.GetText()
+
+	switch {
+	case ctx.DecimalIntegerLiteral() != nil, ctx.BinaryIntegerLiteral() != nil,
+		ctx.OctalIntegerLiteral() != nil, ctx.HexIntegerLiteral() != nil:
+		value, err := strconv.ParseInt(strings.ReplaceAll(text, "_", ""), 0, 64)
+		if err != nil {
+			// Leading zeros are decimal in OpenQASM, not octal
+			value, _ = strconv.ParseInt(strings.TrimLeft(strings.ReplaceAll(text, "_", ""), "0")+"0", 10, 64)
+			value /= 10
+		}
+		return &IntegerLiteral{BaseNode: base, Value: value}
+
+	case ctx.FloatLiteral() != nil:
+		value, _ := strconv.ParseFloat(strings.ReplaceAll(text, "_", ""), 64)
+		return &FloatLiteral{BaseNode: base, Value: value}
+
+	case ctx.BooleanLiteral() != nil:
+		return &BooleanLiteral{BaseNode: base, Value: text == "true"}
+
+	case ctx.BitstringLiteral() != nil:
+		return &StringLiteral{BaseNode: base, Value: strings.Trim(text, "\"")}
+
+	case ctx.TimingLiteral() != nil:
+		return v.buildTimingExpression(base, text)
+	}
+
+	// Identifiers, hardware qubits ($0) and imaginary literals
+	return &Identifier{BaseNode: base, Name: text}
+}
+
+// buildTimingExpression splits a timing literal such as 100ns or 1.5 us into value and unit
+func (v *ASTBuilderVisitor) buildTimingExpression(base BaseNode, text string) Expression {
+	split := strings.IndexFunc(text, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != '_' && r != 'e' && r != 'E' && r != '+' && r != '-'
+	})
+	if split == -1 {
+		split = len(text)
+	}
+
+	number := strings.TrimSpace(strings.ReplaceAll(text[:split], "_", ""))
+	unit := strings.TrimSpace(text[split:])
+
+	var value Expression
+	if intValue, err := strconv.ParseInt(number, 10, 64); err == nil {
+		value = &IntegerLiteral{BaseNode: base, Value: intValue}
+	} else {
+		floatValue, _ := strconv.ParseFloat(number, 64)
+		value = &FloatLiteral{BaseNode: base, Value: floatValue}
+	}
+
+	return &TimingExpression{
+		BaseNode: base,
+		Value:    value,
+		Unit:     unit,
+	}
+}
+
+// visitAliasExpression builds the value of a let statement, joining concatenations with "++"
+func (v *ASTBuilderVisitor) visitAliasExpression(ctx qasm_gen.IAliasExpressionContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	var result Expression
+	for _, exprCtx := range ctx.AllExpression() {
+		expr := v.visitExpression(exprCtx)
+		if result == nil {
+			result = expr
+			continue
+		}
+		result = &BinaryExpression{
+			BaseNode: v.createBaseNode(ctx),
+			Left:     result,
+			Operator: "++",
+			Right:    expr,
+		}
+	}
+	return result
+}
+
+// visitDeclarationExpression builds the initializer of a declaration
+func (v *ASTBuilderVisitor) visitDeclarationExpression(ctx qasm_gen.IDeclarationExpressionContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	if exprCtx := ctx.Expression(); exprCtx != nil {
+		return v.visitExpression(exprCtx)
+	}
+
+	// Measurement initializers (bit c = measure q) are represented as a call to measure
+	if measureCtx := ctx.MeasureExpression(); measureCtx != nil {
+		return &FunctionCall{
+			BaseNode:  v.createBaseNode(measureCtx),
+			Name:      "measure",
+			Arguments: []Expression{v.visitGateOperand(measureCtx.GateOperand())},
+		}
+	}
+
+	// Array literals are not represented yet
+	return nil
+}
+
+// visitGateOperand builds an operand expression (q, q[0], q[0:2] or $0) with positions
+func (v *ASTBuilderVisitor) visitGateOperand(ctx qasm_gen.IGateOperandContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	if hw := ctx.HardwareQubit(); hw != nil {
+		return &Identifier{
+			BaseNode: v.createBaseNode(ctx),
+			Name:     hw.GetText(),
+		}
+	}
+
+	return v.visitIndexedIdentifierContext(ctx.IndexedIdentifier())
+}
+
+// visitIndexedIdentifierContext builds an identifier with optional index from an indexedIdentifier rule
+func (v *ASTBuilderVisitor) visitIndexedIdentifierContext(ctx qasm_gen.IIndexedIdentifierContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	name := ctx.Identifier().GetText()
+	indexOps := ctx.AllIndexOperator()
+	if len(indexOps) == 0 {
+		return &Identifier{
+			BaseNode: v.createBaseNode(ctx),
+			Name:     name,
+		}
+	}
+
+	// Only the first index operator is represented; multi-dimensional access is rare for registers
+	return v.buildIndexedExpression(ctx, name, indexOps[0])
+}
+
+// buildIndexedExpression creates an IndexedIdentifier or RangedIdentifier for name[index]
+func (v *ASTBuilderVisitor) buildIndexedExpression(ctx antlr.ParserRuleContext, name string, indexOp qasm_gen.IIndexOperatorContext) Expression {
+	base := v.createBaseNode(ctx)
+
+	if indexOp != nil {
+		if ranges := indexOp.AllRangeExpression(); len(ranges) == 1 && len(indexOp.AllExpression()) == 0 {
+			ranged := &RangedIdentifier{BaseNode: base, Name: name}
+			ranged.Start, ranged.Step, ranged.EndIndex = v.visitRangeBounds(ranges[0])
+			return ranged
+		}
+
+		if exprs := indexOp.AllExpression(); len(exprs) == 1 {
+			return &IndexedIdentifier{
+				BaseNode: base,
+				Name:     name,
+				Index:    v.visitExpression(exprs[0]),
+			}
+		}
+	}
+
+	// Sets and multi-dimensional indices keep their source text as the index
+	return &IndexedIdentifier{
+		BaseNode: base,
+		Name:     name,
+		Index: &Identifier{
+			BaseNode: base,
+			Name:     strings.TrimSuffix(strings.TrimPrefix(indexOp.GetText(), "["), "]"),
+		},
+	}
+}
+
+// visitRangeBounds returns the start, step and end of a range expression.
+// Expressions are assigned by their position relative to the colons (start:end or start:step:end).
+func (v *ASTBuilderVisitor) visitRangeBounds(ctx qasm_gen.IRangeExpressionContext) (start, step, end Expression) {
+	colons := ctx.AllCOLON()
+	for _, expr := range ctx.AllExpression() {
+		offset := expr.GetStart().GetStart()
+		switch {
+		case offset < colons[0].GetSymbol().GetStart():
+			start = v.visitExpression(expr)
+		case len(colons) > 1 && offset < colons[1].GetSymbol().GetStart():
+			step = v.visitExpression(expr)
+		default:
+			end = v.visitExpression(expr)
+		}
+	}
+	return start, step, end
+}
+
+// visitRangeExpression handles standalone ranges such as a loop iterable [0:n]
+func (v *ASTBuilderVisitor) visitRangeExpression(ctx qasm_gen.IRangeExpressionContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	rangeExpr := &RangeExpression{BaseNode: v.createBaseNode(ctx)}
+	rangeExpr.Start, rangeExpr.Step, rangeExpr.EndIndex = v.visitRangeBounds(ctx)
+	return rangeExpr
+}
+
+// visitSetExpression handles discrete sets such as a loop iterable {0, 2, 5}
+func (v *ASTBuilderVisitor) visitSetExpression(ctx qasm_gen.ISetExpressionContext) Expression {
+	if ctx == nil {
+		return nil
+	}
+
+	set := &SetExpression{BaseNode: v.createBaseNode(ctx)}
+	for _, exprCtx := range ctx.AllExpression() {
+		if expr := v.visitExpression(exprCtx); expr != nil {
+			set.Elements = append(set.Elements, expr)
+		}
+	}
+	return set
+}
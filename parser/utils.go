@@ -53,6 +53,14 @@ func (p *Program) statementToQASM(stmt Statement) string {
 		return p.gateCallToQASM(s)
 	case *Measurement:
 		return p.measurementToQASM(s)
+	case *Reset:
+		return fmt.Sprintf("reset %s;", p.expressionToQASM(s.Qubit))
+	case *AliasDeclaration:
+		return fmt.Sprintf("let %s = %s;", s.Identifier, p.expressionToQASM(s.Value))
+	case *ExpressionStatement:
+		return p.expressionToQASM(s.Expression) + ";"
+	case *Assignment:
+		return fmt.Sprintf("%s %s %s;", p.expressionToQASM(s.Target), s.Operator, p.expressionToQASM(s.Value))
 	case *Include:
 		return fmt.Sprintf("include \"%s\";", s.Path)
 	case *GateDefinition:
@@ -63,12 +71,32 @@ func (p *Program) statementToQASM(stmt Statement) string {
 		return p.forStmtToQASM(s)
 	case *WhileStatement:
 		return p.whileStmtToQASM(s)
+	case *DelayStatement:
+		return p.delayStmtToQASM(s)
+	case *BoxStatement:
+		return p.boxStmtToQASM(s)
+	case *SubroutineDefinition:
+		return p.subroutineDefToQASM(s)
+	case *BreakStatement:
+		return "break;"
+	case *ContinueStatement:
+		return "continue;"
+	case *ReturnStatement:
+		if s.Value != nil {
+			return "return " + p.expressionToQASM(s.Value) + ";"
+		}
+		return "return;"
+	case *EndStatement:
+		return "end;"
 	default:
 		return "// Unknown statement type"
 	}
 }
 
 func (p *Program) quantumDeclToQASM(q *QuantumDeclaration) string {
+	if q.Type == "qreg" {
+		return p.registerDeclToQASM(q.Type, q.Identifier, q.Size)
+	}
 	if q.Size != nil {
 		return fmt.Sprintf("%s[%s] %s;", q.Type, p.expressionToQASM(q.Size), q.Identifier)
 	}
@@ -76,6 +104,9 @@ func (p *Program) quantumDeclToQASM(q *QuantumDeclaration) string {
 }
 
 func (p *Program) classicalDeclToQASM(c *ClassicalDeclaration) string {
+	if c.Type == "creg" {
+		return p.registerDeclToQASM(c.Type, c.Identifier, c.Size)
+	}
 	result := c.Type
 	if c.Size != nil {
 		result += fmt.Sprintf("[%s]", p.expressionToQASM(c.Size))
@@ -87,6 +118,14 @@ func (p *Program) classicalDeclToQASM(c *ClassicalDeclaration) string {
 	return result + ";"
 }
 
+// registerDeclToQASM formats an OpenQASM 2 qreg or creg declaration
+func (p *Program) registerDeclToQASM(keyword, identifier string, size Expression) string {
+	if size != nil {
+		return fmt.Sprintf("%s %s[%s];", keyword, identifier, p.expressionToQASM(size))
+	}
+	return fmt.Sprintf("%s %s;", keyword, identifier)
+}
+
 func (p *Program) gateCallToQASM(g *GateCall) string {
 	result := g.Name
 	if len(g.Parameters) > 0 {
@@ -171,6 +210,23 @@ func (p *Program) forStmtToQASM(f *ForStatement) string {
 	return result
 }
 
+func (p *Program) subroutineDefToQASM(s *SubroutineDefinition) string {
+	params := make([]string, len(s.Parameters))
+	for i, param := range s.Parameters {
+		params[i] = strings.TrimSpace(param.Type + " " + param.Name)
+	}
+	result := fmt.Sprintf("def %s(%s)", s.Name, strings.Join(params, ", "))
+	if s.ReturnType != "" {
+		result += " -> " + s.ReturnType
+	}
+	result += " {\n"
+	for _, stmt := range s.Body {
+		result += "  " + p.statementToQASM(stmt) + "\n"
+	}
+	result += "}"
+	return result
+}
+
 func (p *Program) whileStmtToQASM(w *WhileStatement) string {
 	result := "while (" + p.expressionToQASM(w.Condition) + ") {\n"
 	for _, stmt := range w.Body {
@@ -180,6 +236,31 @@ func (p *Program) whileStmtToQASM(w *WhileStatement) string {
 	return result
 }
 
+func (p *Program) delayStmtToQASM(d *DelayStatement) string {
+	result := "delay[" + p.expressionToQASM(d.Duration) + "]"
+	if len(d.Qubits) > 0 {
+		qubits := make([]string, len(d.Qubits))
+		for i, qubit := range d.Qubits {
+			qubits[i] = p.expressionToQASM(qubit)
+		}
+		result += " " + strings.Join(qubits, ", ")
+	}
+	return result + ";"
+}
+
+func (p *Program) boxStmtToQASM(b *BoxStatement) string {
+	result := "box"
+	if b.Duration != nil {
+		result += "[" + p.expressionToQASM(b.Duration) + "]"
+	}
+	result += " {\n"
+	for _, stmt := range b.Body {
+		result += "  " + p.statementToQASM(stmt) + "\n"
+	}
+	result += "}"
+	return result
+}
+
 func (p *Program) expressionToQASM(expr Expression) string {
 	switch e := expr.(type) {
 	case *Identifier:
@@ -188,6 +269,19 @@ func (p *Program) expressionToQASM(expr Expression) string {
 		return fmt.Sprintf("%s[%s]", e.Name, p.expressionToQASM(e.Index))
 	case *RangedIdentifier:
 		return fmt.Sprintf("%s[%s:%s]", e.Name, p.expressionToQASM(e.Start), p.expressionToQASM(e.EndIndex))
+	case *RangeExpression:
+		bounds := []string{p.optionalExpressionToQASM(e.Start)}
+		if e.Step != nil {
+			bounds = append(bounds, p.expressionToQASM(e.Step))
+		}
+		bounds = append(bounds, p.optionalExpressionToQASM(e.EndIndex))
+		return "[" + strings.Join(bounds, ":") + "]"
+	case *SetExpression:
+		elements := make([]string, len(e.Elements))
+		for i, element := range e.Elements {
+			elements[i] = p.expressionToQASM(element)
+		}
+		return "{" + strings.Join(elements, ", ") + "}"
 	case *IntegerLiteral:
 		return fmt.Sprintf("%d", e.Value)
 	case *FloatLiteral:
@@ -211,11 +305,21 @@ func (p *Program) expressionToQASM(expr Expression) string {
 		return fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", "))
 	case *ParenthesizedExpression:
 		return "(" + p.expressionToQASM(e.Expression) + ")"
+	case *TimingExpression:
+		return p.expressionToQASM(e.Value) + e.Unit
 	default:
 		return "/* unknown expression */"
 	}
 }
 
+// optionalExpressionToQASM converts an expression that may be omitted, such as a range bound
+func (p *Program) optionalExpressionToQASM(expr Expression) string {
+	if expr == nil {
+		return ""
+	}
+	return p.expressionToQASM(expr)
+}
+
 // Validate performs semantic validation on the AST
 func (p *Program) Validate() []ValidationError {
 	// Basic validation rules
@@ -1,6 +1,9 @@
 package parser
 
-// Visitor interface for AST traversal
+// Visitor interface for AST traversal. Node types added after it are visited
+// through optional interfaces such as ResetVisitor, so that existing
+// implementations keep compiling; Walk skips those nodes for visitors that do
+// not implement their interface.
 type Visitor interface {
 	VisitProgram(node *Program) interface{}
 	VisitVersion(node *Version) interface{}
@@ -35,6 +38,71 @@ type Visitor interface {
 	VisitParameter(node *Parameter) interface{}
 }
 
+// ResetVisitor is implemented by visitors of reset statements
+type ResetVisitor interface {
+	VisitReset(node *Reset) interface{}
+}
+
+// AliasDeclarationVisitor is implemented by visitors of alias declarations
+type AliasDeclarationVisitor interface {
+	VisitAliasDeclaration(node *AliasDeclaration) interface{}
+}
+
+// RangeExpressionVisitor is implemented by visitors of range expressions
+type RangeExpressionVisitor interface {
+	VisitRangeExpression(node *RangeExpression) interface{}
+}
+
+// SetExpressionVisitor is implemented by visitors of set expressions
+type SetExpressionVisitor interface {
+	VisitSetExpression(node *SetExpression) interface{}
+}
+
+// AssignmentVisitor is implemented by visitors of assignments
+type AssignmentVisitor interface {
+	VisitAssignment(node *Assignment) interface{}
+}
+
+// SubroutineDefinitionVisitor is implemented by visitors of subroutine definitions
+type SubroutineDefinitionVisitor interface {
+	VisitSubroutineDefinition(node *SubroutineDefinition) interface{}
+}
+
+// BreakStatementVisitor is implemented by visitors of break statements
+type BreakStatementVisitor interface {
+	VisitBreakStatement(node *BreakStatement) interface{}
+}
+
+// ContinueStatementVisitor is implemented by visitors of continue statements
+type ContinueStatementVisitor interface {
+	VisitContinueStatement(node *ContinueStatement) interface{}
+}
+
+// ReturnStatementVisitor is implemented by visitors of return statements
+type ReturnStatementVisitor interface {
+	VisitReturnStatement(node *ReturnStatement) interface{}
+}
+
+// EndStatementVisitor is implemented by visitors of end statements
+type EndStatementVisitor interface {
+	VisitEndStatement(node *EndStatement) interface{}
+}
+
+// ExpressionStatementVisitor is implemented by visitors of expression statements
+type ExpressionStatementVisitor interface {
+	VisitExpressionStatement(node *ExpressionStatement) interface{}
+}
+
+// DelayStatementVisitor is implemented by visitors of delay statements
+type DelayStatementVisitor interface {
+	VisitDelayStatement(node *DelayStatement) interface{}
+}
+
+// BoxStatementVisitor is implemented by visitors of box statements
+type BoxStatementVisitor interface {
+	VisitBoxStatement(node *BoxStatement) interface{}
+}
+
 // BaseVisitor provides default implementations that return nil
 type BaseVisitor struct{}
 
@@ -43,16 +111,29 @@ func (v *BaseVisitor) VisitVersion(node *Version) interface{}
 func (v *BaseVisitor) VisitComment(node *Comment) interface{}                           { return nil }
 func (v *BaseVisitor) VisitQuantumDeclaration(node *QuantumDeclaration) interface{}     { return nil }
 func (v *BaseVisitor) VisitClassicalDeclaration(node *ClassicalDeclaration) interface{} { return nil }
+func (v *BaseVisitor) VisitAliasDeclaration(node *AliasDeclaration) interface{}         { return nil }
 func (v *BaseVisitor) VisitGateCall(node *GateCall) interface{}                         { return nil }
 func (v *BaseVisitor) VisitMeasurement(node *Measurement) interface{}                   { return nil }
+func (v *BaseVisitor) VisitReset(node *Reset) interface{}                               { return nil }
+func (v *BaseVisitor) VisitAssignment(node *Assignment) interface{}                     { return nil }
+func (v *BaseVisitor) VisitExpressionStatement(node *ExpressionStatement) interface{}   { return nil }
 func (v *BaseVisitor) VisitInclude(node *Include) interface{}                           { return nil }
 func (v *BaseVisitor) VisitGateDefinition(node *GateDefinition) interface{}             { return nil }
 func (v *BaseVisitor) VisitIfStatement(node *IfStatement) interface{}                   { return nil }
 func (v *BaseVisitor) VisitForStatement(node *ForStatement) interface{}                 { return nil }
 func (v *BaseVisitor) VisitWhileStatement(node *WhileStatement) interface{}             { return nil }
+func (v *BaseVisitor) VisitDelayStatement(node *DelayStatement) interface{}             { return nil }
+func (v *BaseVisitor) VisitBoxStatement(node *BoxStatement) interface{}                 { return nil }
+func (v *BaseVisitor) VisitSubroutineDefinition(node *SubroutineDefinition) interface{} { return nil }
+func (v *BaseVisitor) VisitBreakStatement(node *BreakStatement) interface{}             { return nil }
+func (v *BaseVisitor) VisitContinueStatement(node *ContinueStatement) interface{}       { return nil }
+func (v *BaseVisitor) VisitReturnStatement(node *ReturnStatement) interface{}           { return nil }
+func (v *BaseVisitor) VisitEndStatement(node *EndStatement) interface{}                 { return nil }
 func (v *BaseVisitor) VisitIdentifier(node *Identifier) interface{}                     { return nil }
 func (v *BaseVisitor) VisitIndexedIdentifier(node *IndexedIdentifier) interface{}       { return nil }
 func (v *BaseVisitor) VisitRangedIdentifier(node *RangedIdentifier) interface{}         { return nil }
+func (v *BaseVisitor) VisitRangeExpression(node *RangeExpression) interface{}           { return nil }
+func (v *BaseVisitor) VisitSetExpression(node *SetExpression) interface{}               { return nil }
 func (v *BaseVisitor) VisitIntegerLiteral(node *IntegerLiteral) interface{}             { return nil }
 func (v *BaseVisitor) VisitFloatLiteral(node *FloatLiteral) interface{}                 { return nil }
 func (v *BaseVisitor) VisitStringLiteral(node *StringLiteral) interface{}               { return nil }
@@ -83,10 +164,18 @@ func Walk(visitor Visitor, node Node) interface{} {
 		return visitor.VisitQuantumDeclaration(n)
 	case *ClassicalDeclaration:
 		return visitor.VisitClassicalDeclaration(n)
+	case *AliasDeclaration:
+		return visitOptional(visitor, func(v AliasDeclarationVisitor) interface{} { return v.VisitAliasDeclaration(n) })
 	case *GateCall:
 		return visitor.VisitGateCall(n)
 	case *Measurement:
 		return visitor.VisitMeasurement(n)
+	case *Reset:
+		return visitOptional(visitor, func(v ResetVisitor) interface{} { return v.VisitReset(n) })
+	case *Assignment:
+		return visitOptional(visitor, func(v AssignmentVisitor) interface{} { return v.VisitAssignment(n) })
+	case *ExpressionStatement:
+		return visitOptional(visitor, func(v ExpressionStatementVisitor) interface{} { return v.VisitExpressionStatement(n) })
 	case *Include:
 		return visitor.VisitInclude(n)
 	case *GateDefinition:
@@ -97,12 +186,30 @@ func Walk(visitor Visitor, node Node) interface{} {
 		return visitor.VisitForStatement(n)
 	case *WhileStatement:
 		return visitor.VisitWhileStatement(n)
+	case *DelayStatement:
+		return visitOptional(visitor, func(v DelayStatementVisitor) interface{} { return v.VisitDelayStatement(n) })
+	case *BoxStatement:
+		return visitOptional(visitor, func(v BoxStatementVisitor) interface{} { return v.VisitBoxStatement(n) })
+	case *SubroutineDefinition:
+		return visitOptional(visitor, func(v SubroutineDefinitionVisitor) interface{} { return v.VisitSubroutineDefinition(n) })
+	case *BreakStatement:
+		return visitOptional(visitor, func(v BreakStatementVisitor) interface{} { return v.VisitBreakStatement(n) })
+	case *ContinueStatement:
+		return visitOptional(visitor, func(v ContinueStatementVisitor) interface{} { return v.VisitContinueStatement(n) })
+	case *ReturnStatement:
+		return visitOptional(visitor, func(v ReturnStatementVisitor) interface{} { return v.VisitReturnStatement(n) })
+	case *EndStatement:
+		return visitOptional(visitor, func(v EndStatementVisitor) interface{} { return v.VisitEndStatement(n) })
 	case *Identifier:
 		return visitor.VisitIdentifier(n)
 	case *IndexedIdentifier:
 		return visitor.VisitIndexedIdentifier(n)
 	case *RangedIdentifier:
 		return visitor.VisitRangedIdentifier(n)
+	case *RangeExpression:
+		return visitOptional(visitor, func(v RangeExpressionVisitor) interface{} { return v.VisitRangeExpression(n) })
+	case *SetExpression:
+		return visitOptional(visitor, func(v SetExpressionVisitor) interface{} { return v.VisitSetExpression(n) })
 	case *IntegerLiteral:
 		return visitor.VisitIntegerLiteral(n)
 	case *FloatLiteral:
@@ -129,6 +236,15 @@ func Walk(visitor Visitor, node Node) interface{} {
 	}
 }
 
+// visitOptional dispatches to an optional visitor interface, returning nil
+// when the visitor does not implement it
+func visitOptional[V any](visitor Visitor, visit func(V) interface{}) interface{} {
+	if v, ok := visitor.(V); ok {
+		return visit(v)
+	}
+	return nil
+}
+
 // WalkStatements traverses a slice of statements
 func WalkStatements(visitor Visitor, statements []Statement) []interface{} {
 	results := make([]interface{}, len(statements))
@@ -189,6 +305,31 @@ func (d *DepthFirstVisitor) VisitMeasurement(node *Measurement) interface{} {
 	return result
 }
 
+func (d *DepthFirstVisitor) VisitAliasDeclaration(node *AliasDeclaration) interface{} {
+	result := visitOptional(d.visitor, func(v AliasDeclarationVisitor) interface{} { return v.VisitAliasDeclaration(node) })
+	Walk(d, node.Value)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitReset(node *Reset) interface{} {
+	result := visitOptional(d.visitor, func(v ResetVisitor) interface{} { return v.VisitReset(node) })
+	Walk(d, node.Qubit)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitAssignment(node *Assignment) interface{} {
+	result := visitOptional(d.visitor, func(v AssignmentVisitor) interface{} { return v.VisitAssignment(node) })
+	Walk(d, node.Target)
+	Walk(d, node.Value)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitExpressionStatement(node *ExpressionStatement) interface{} {
+	result := visitOptional(d.visitor, func(v ExpressionStatementVisitor) interface{} { return v.VisitExpressionStatement(node) })
+	Walk(d, node.Expression)
+	return result
+}
+
 func (d *DepthFirstVisitor) VisitGateDefinition(node *GateDefinition) interface{} {
 	result := d.visitor.VisitGateDefinition(node)
 	for _, param := range node.Parameters {
@@ -223,6 +364,35 @@ func (d *DepthFirstVisitor) VisitWhileStatement(node *WhileStatement) interface{
 	return result
 }
 
+func (d *DepthFirstVisitor) VisitDelayStatement(node *DelayStatement) interface{} {
+	result := visitOptional(d.visitor, func(v DelayStatementVisitor) interface{} { return v.VisitDelayStatement(node) })
+	Walk(d, node.Duration)
+	WalkExpressions(d, node.Qubits)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitBoxStatement(node *BoxStatement) interface{} {
+	result := visitOptional(d.visitor, func(v BoxStatementVisitor) interface{} { return v.VisitBoxStatement(node) })
+	Walk(d, node.Duration)
+	WalkStatements(d, node.Body)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitSubroutineDefinition(node *SubroutineDefinition) interface{} {
+	result := visitOptional(d.visitor, func(v SubroutineDefinitionVisitor) interface{} { return v.VisitSubroutineDefinition(node) })
+	for _, param := range node.Parameters {
+		Walk(d, &param)
+	}
+	WalkStatements(d, node.Body)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitReturnStatement(node *ReturnStatement) interface{} {
+	result := visitOptional(d.visitor, func(v ReturnStatementVisitor) interface{} { return v.VisitReturnStatement(node) })
+	Walk(d, node.Value)
+	return result
+}
+
 func (d *DepthFirstVisitor) VisitIndexedIdentifier(node *IndexedIdentifier) interface{} {
 	result := d.visitor.VisitIndexedIdentifier(node)
 	Walk(d, node.Index)
@@ -232,10 +402,25 @@ func (d *DepthFirstVisitor) VisitIndexedIdentifier(node *IndexedIdentifier) inte
 func (d *DepthFirstVisitor) VisitRangedIdentifier(node *RangedIdentifier) interface{} {
 	result := d.visitor.VisitRangedIdentifier(node)
 	Walk(d, node.Start)
+	Walk(d, node.Step)
+	Walk(d, node.EndIndex)
+	return result
+}
+
+func (d *DepthFirstVisitor) VisitRangeExpression(node *RangeExpression) interface{} {
+	result := visitOptional(d.visitor, func(v RangeExpressionVisitor) interface{} { return v.VisitRangeExpression(node) })
+	Walk(d, node.Start)
+	Walk(d, node.Step)
 	Walk(d, node.EndIndex)
 	return result
 }
 
+func (d *DepthFirstVisitor) VisitSetExpression(node *SetExpression) interface{} {
+	result := visitOptional(d.visitor, func(v SetExpressionVisitor) interface{} { return v.VisitSetExpression(node) })
+	WalkExpressions(d, node.Elements)
+	return result
+}
+
 func (d *DepthFirstVisitor) VisitBinaryExpression(node *BinaryExpression) interface{} {
 	result := d.visitor.VisitBinaryExpression(node)
 	Walk(d, node.Left)
@@ -283,6 +468,15 @@ func (d *DepthFirstVisitor) VisitClassicalDeclaration(node *ClassicalDeclaration
 func (d *DepthFirstVisitor) VisitInclude(node *Include) interface{} {
 	return d.visitor.VisitInclude(node)
 }
+func (d *DepthFirstVisitor) VisitBreakStatement(node *BreakStatement) interface{} {
+	return visitOptional(d.visitor, func(v BreakStatementVisitor) interface{} { return v.VisitBreakStatement(node) })
+}
+func (d *DepthFirstVisitor) VisitContinueStatement(node *ContinueStatement) interface{} {
+	return visitOptional(d.visitor, func(v ContinueStatementVisitor) interface{} { return v.VisitContinueStatement(node) })
+}
+func (d *DepthFirstVisitor) VisitEndStatement(node *EndStatement) interface{} {
+	return visitOptional(d.visitor, func(v EndStatementVisitor) interface{} { return v.VisitEndStatement(node) })
+}
 func (d *DepthFirstVisitor) VisitIdentifier(node *Identifier) interface{} {
 	return d.visitor.VisitIdentifier(node)
 }
@@ -139,28 +139,39 @@ export default function Playground() {
 
         // Only set markers on the input editor if we're not linting output
         // When linting output, we don't set markers since output editor is read-only
-        if (!isOutputCode && editorRef.current) {
+        const model = editorRef.current?.getModel();
+        if (!isOutputCode && model) {
           // Convert violations to Monaco markers
           const markers = result.violations.map(violation => ({
             startLineNumber: violation.line,
             startColumn: violation.column,
-            endLineNumber: violation.line,
-            endColumn: violation.column + 10, // Estimate end column
+            // end_column is the last character of the violation; Monaco's end is exclusive
+            endLineNumber: violation.end_line || violation.line,
+            endColumn: violation.end_line ? (violation.end_column ?? violation.column) + 1 : violation.column + 10,
             message: `${violation.message} (${violation.rule_id})`,
             severity: violation.severity === 'error' ?
               monacoInstance.MarkerSeverity.Error :
               violation.severity === 'warning' ?
                 monacoInstance.MarkerSeverity.Warning :
                 monacoInstance.MarkerSeverity.Info,
+            tags: violation.tags?.flatMap(tag =>
+              tag === 'unnecessary' ? [monacoInstance.MarkerTag.Unnecessary] :
+              tag === 'deprecated' ? [monacoInstance.MarkerTag.Deprecated] : []
+            ),
+            relatedInformation: violation.related?.map(related => ({
+              resource: model.uri,
+              message: related.message,
+              startLineNumber: related.line,
+              startColumn: related.column,
+              endLineNumber: related.line,
+              endColumn: related.column + 1
+            })),
             code: violation.rule_id,
             source: 'qasm-lint'
           }));
 
           // Set markers on the model
-          const model = editorRef.current.getModel();
-          if (model) {
-            monacoInstance.editor.setModelMarkers(model, 'qasm-lint', markers);
-          }
+          monacoInstance.editor.setModelMarkers(model, 'qasm-lint', markers);
         }
       } else if (!result.success) {
         console.warn('Linting failed:', result.error);
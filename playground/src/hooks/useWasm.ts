@@ -22,14 +22,37 @@ export interface HighlightResult {
   error?: string;
 }
 
+export interface SourcePosition {
+  line: number;
+  column: number;
+  offset: number;
+}
+
 export interface Violation {
   file: string;
   line: number;
   column: number;
+  end_line?: number;
+  end_column?: number;
   severity: string;
   rule_id: string;
   message: string;
   documentation_url: string;
+  tags?: string[];
+  related?: {
+    file: string;
+    line: number;
+    column: number;
+    message: string;
+  }[];
+  fix?: {
+    description: string;
+    edits: {
+      start: SourcePosition;
+      end: SourcePosition;
+      new_text: string;
+    }[];
+  };
   rule_details?: {
     name: string;
     description: string;
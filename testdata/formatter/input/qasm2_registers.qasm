@@ -0,0 +1,6 @@
+OPENQASM 3.0;
+include "qelib1.inc";
+qreg   q[2];
+creg c [2];
+CX q[0],q[1];
+measure q->c;
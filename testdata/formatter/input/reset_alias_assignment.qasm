@@ -0,0 +1,6 @@
+OPENQASM 3.0;
+qubit[2] q;
+int[32] n;
+reset q[0];
+let pair = q[0:1];
+n = 3;
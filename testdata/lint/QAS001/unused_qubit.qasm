@@ -0,0 +1,11 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q;
+qubit ancilla; // want QAS001 "Qubit 'ancilla' is declared but never used"
+qubit spare; // want QAS001 "'spare'"
+bit[2] c;
+
+h q[0];
+cx q[0], q[1];
+c = measure q;
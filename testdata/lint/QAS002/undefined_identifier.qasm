@@ -0,0 +1,11 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q;
+bit[2] c;
+
+h q[0];
+cx q[0], r[1]; // want QAS002 "Identifier 'r' is not declared"
+c[0] = measure q[0];
+measure q[1] -> d[0]; // want QAS002 "'d'"
+x $0;
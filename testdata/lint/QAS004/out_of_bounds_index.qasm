@@ -0,0 +1,11 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q;
+bit[3] c;
+
+h q[0];
+h q[2]; // want QAS004 "accessing '2' on 'q' of length 2"
+cx q[0], q[1];
+c[0] = measure q[0];
+c[3] = measure q[1]; // want QAS004 "on 'c' of length 3"
@@ -0,0 +1,23 @@
+OPENQASM 3.0;
+
+bit c;
+
+break; // want QAS009 "'break' cannot be used outside of a loop"
+
+for int i in [0:3] {
+    if (c) {
+        break;
+    }
+    continue;
+}
+
+while (c) {
+    // break and continue in comments are ignored
+    box {
+        continue;
+    }
+}
+
+def f() {
+    continue; // want QAS009 "'continue'"
+}
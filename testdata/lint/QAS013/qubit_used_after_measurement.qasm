@@ -0,0 +1,16 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q;
+bit[2] c;
+
+h q[0];
+h q[1];
+c[0] = measure q[0];
+x q[0]; // want QAS013
+reset q[0];
+h q[0];
+measure q[1] -> c[1];
+if (c[1] == 1) {
+    x q[1];
+}
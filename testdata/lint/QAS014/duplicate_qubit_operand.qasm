@@ -0,0 +1,10 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[3] q;
+
+cx q[0], q[0]; // want QAS014
+cx q[0], q[1];
+ccx q[0], q[1], q[2];
+cx q, q[1]; // want QAS014
+cx q[0:1], q[1:2];
@@ -0,0 +1,17 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q;
+int[32] n = 2;
+qubit[2] q; // want QAS016
+
+for int n in [0:1] { // want QAS016
+    x q[n];
+}
+
+bit c;
+if (c) {
+    int[32] k = 1;
+} else {
+    int[32] k = 2;
+}
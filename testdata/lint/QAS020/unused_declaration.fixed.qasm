@@ -0,0 +1,9 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit q;
+ // want QAS020
+float[64] theta = 0.5;
+
+
+rx(theta) q;
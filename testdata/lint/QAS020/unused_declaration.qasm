@@ -0,0 +1,12 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit q;
+int[32] unused = 3; // want QAS020
+float[64] theta = 0.5;
+
+gate unused_gate a { // want QAS020
+    x a;
+}
+
+rx(theta) q;
@@ -0,0 +1,9 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit[2] q; // want QAS021
+bit[2] c; // want QAS021
+
+h q[0];
+cx q[0], q[1]; // want QAS021
+measure q -> c;
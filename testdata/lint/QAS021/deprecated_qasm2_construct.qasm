@@ -0,0 +1,9 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qreg q[2]; // want QAS021
+creg c[2]; // want QAS021
+
+h q[0];
+CX q[0], q[1]; // want QAS021
+measure q -> c;
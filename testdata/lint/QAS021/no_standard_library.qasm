@@ -0,0 +1,6 @@
+OPENQASM 3.0;
+
+// Without stdgates.inc there is no 'cx' to rename CX to
+qreg q[2]; // want QAS021
+CX q[0], q[1]; // want QAS021 "use 'cx' from stdgates.inc"
+U(0, 0, 0) q[0]; // want QAS021 "use 'u3' from stdgates.inc"
@@ -0,0 +1,10 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+qubit q;
+
+rx(pi / 2) q;
+p(3 * pi) q; // want QAS022
+ry(pi/2) q; // want QAS022
+rz(0.5) q;
+rz(720) q; // want QAS022 "exceeds 2π"
@@ -0,0 +1,9 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+include "gates.inc";
+
+qubit[2] q;
+bit[2] c;
+
+bell q[0], q[1];
+c = measure q;
@@ -0,0 +1,9 @@
+OPENQASM 3.0;
+include "gates.inc";
+
+qubit[4] q;
+bit[4] c;
+
+// Gates count as used from any file of the project
+swap_pair q[0], q[1], q[2], q[3];
+c = measure q;
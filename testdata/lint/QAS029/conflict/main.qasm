@@ -0,0 +1,8 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+include "ising.inc";
+include "native.inc"; // want QAS029 "Gate 'rzz' is defined with 0 parameters and 2 qubits in 'native.inc' but with 1 parameter and 2 qubits in 'ising.inc'." QAS029 "Gate 'rx'"
+
+qubit[2] q;
+rzz(0.5) q[0], q[1];
+cz_pair q[0], q[1];
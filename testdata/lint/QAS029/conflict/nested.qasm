@@ -0,0 +1,7 @@
+OPENQASM 3.0;
+include "ising.inc";
+// Conflicts brought in by nested includes are reported on the outer include
+include "wrapper.inc"; // want QAS029 "Gate 'rzz'" QAS029 "Gate 'rx' is defined with 0 parameters and 1 qubit in 'native.inc' but with 1 parameter and 1 qubit in 'stdgates.inc'."
+
+qubit[2] q;
+rzz(0.5) q[0], q[1];
@@ -0,0 +1,19 @@
+OPENQASM 3.0;
+include "stdgates.inc";
+
+const int n = 3;
+qubit[4] q;
+qubit[n] r;
+bit[4] c;
+bit[2] pair;
+int[8] count;
+
+h q;
+measure q -> c;
+measure q -> pair; // want QAS030 "Measuring 4 qubits of 'q' into 2 bits of 'pair'"
+pair = measure q[{0, 3}];
+pair = measure q[0:2]; // want QAS030 "3 qubits of 'q' into 2 bits"
+bit[n] result = measure r;
+bit[2] partial = measure r; // want QAS030 "3 qubits of 'r' into 2 bits of 'partial'"
+measure q[0] -> count; // want QAS030 "Cannot store a measurement in 'count' of type int[8]"
+measure pair -> c[0:1]; // want QAS030 "Cannot measure 'pair' of type bit[2]"